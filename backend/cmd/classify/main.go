@@ -1,70 +1,33 @@
 package main
 
 import (
-	"log"
-	"strings"
-
+	"dramabang/database"
 	"dramabang/models"
+	"dramabang/services/jobs"
+	"log"
+	"os"
 
-	"github.com/glebarez/sqlite"
-	"gorm.io/gorm"
+	"github.com/joho/godotenv"
 )
 
-// Heuristic keyword mapping
-var genreKeywords = map[string][]string{
-	"Epic Fantasy & Mythology":   {"naga", "dewa", "legenda", "sakti", "myth", "fantasy", "abadi", "siluman", "langit", "bidadari"},
-	"Martial Arts Action":        {"pendekar", "kungfu", "silat", "jurus", "pedang", "warrior", "fight", "jagoan", "master"},
-	"Historical Romance":         {"kerajaan", "selir", "kaisar", "putri", "pangeran", "dynasty", "colossal", "takhta", "ratu"},
-	"Palace Intrigue & Politics": {"intrik", "politik", "kudeta", "pengkhianatan", "hasutan", "skandal", "selingkuh", "politik"},
-	"Modern Romance & CEO":       {"ceo", "bos", "presdir", "cinta", "nikah", "kontrak", "sekretaris", "miliarder", "kaya", "wealthy"},
-	"School & Youth":             {"sekolah", "kampus", "mahasiswa", "sma", "kuliah", "youth", "remaja", "cinta pertama", "kelas"},
-	"Mystery & Detective":        {"misteri", "detektif", "pembunuhan", "kasus", "investigasi", "rahasia", "hilang", "crime"},
-	"E-Sports & Gaming":          {"game", "esport", "gamer", "kompetisi", "online", "avatar", "dunia maya"},
-}
-
-// Fallback genre if no keywords match
-const DefaultGenre = "Modern Romance & CEO" // Most common in vertical dramas
-
+// Standalone classify; the server runs the same task via /api/admin/jobs
 func main() {
-	dsn := "../../dramabang.db"
-	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
-	if err != nil {
-		log.Fatal(err)
+	if err := godotenv.Load("../../.env"); err != nil {
+		log.Println("No .env file found, using environment variables")
 	}
 
-	// AutoMigrate to ensure Genre column exists
-	db.AutoMigrate(&models.Drama{})
-
-	var dramas []models.Drama
-	db.Find(&dramas)
+	database.Connect()
+	models.MigrateDramas(database.DB)
+	models.MigrateJobs(database.DB)
+	models.MigrateGenres(database.DB)
+	models.MigrateReviews(database.DB)
 
-	log.Printf("Classifying %d dramas...", len(dramas))
-
-	for _, drama := range dramas {
-		detectedGenre := classify(drama.Judul, drama.Deskripsi)
-
-		// Only update if changed or empty
-		if drama.Genre != detectedGenre {
-			drama.Genre = detectedGenre
-			db.Save(&drama)
-			// log.Printf("Classified '%s' as '%s'", drama.Judul, detectedGenre)
-		}
+	job, err := jobs.RunStandalone(database.DB, "classify", jobs.Classify)
+	if err != nil {
+		log.Fatalf("Failed to start classify: %v", err)
 	}
-
-	log.Println("Classification complete!")
-}
-
-func classify(title, desc string) string {
-	text := strings.ToLower(title + " " + desc)
-
-	// Check each genre
-	for genre, keywords := range genreKeywords {
-		for _, kw := range keywords {
-			if strings.Contains(text, kw) {
-				return genre
-			}
-		}
+	log.Printf("Classify job #%d %s: %s", job.ID, job.Status, job.Message)
+	if job.Status != models.JobSucceeded {
+		os.Exit(1)
 	}
-
-	return DefaultGenre
 }
@@ -1,44 +1,32 @@
 package main
 
 import (
+	"dramabang/database"
 	"dramabang/models"
+	"dramabang/services/jobs"
 	"log"
+	"os"
 
-	"github.com/glebarez/sqlite"
-	"gorm.io/gorm"
+	"github.com/joho/godotenv"
 )
 
+// Standalone dedup; the server runs the same task via /api/admin/jobs
 func main() {
-	dsn := "../../dramabang.db"
-	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
-	if err != nil {
-		log.Fatal(err)
-	}
-
-	// Find duplicates
-	type Result struct {
-		Judul string
-		Count int
+	if err := godotenv.Load("../../.env"); err != nil {
+		log.Println("No .env file found, using environment variables")
 	}
 
-	var results []Result
-	db.Model(&models.Drama{}).Select("judul, count(*) as count").Group("judul").Having("count > 1").Find(&results)
-
-	log.Printf("Found %d titles with duplicates", len(results))
+	database.Connect()
+	models.MigrateDramas(database.DB)
+	models.MigrateJobs(database.DB)
+	models.MigrateGenres(database.DB)
 
-	for _, res := range results {
-		var dramas []models.Drama
-		db.Where("judul = ?", res.Judul).Order("book_id desc").Find(&dramas)
-
-		// Keep the first one (latest book_id), delete the rest
-		if len(dramas) > 1 {
-			log.Printf("Deduplicating: %s (Found %d)", res.Judul, len(dramas))
-			for i := 1; i < len(dramas); i++ {
-				log.Printf(" - Deleting duplicate: %s (ID: %s)", dramas[i].Judul, dramas[i].BookID)
-				db.Delete(&dramas[i])
-			}
-		}
+	job, err := jobs.RunStandalone(database.DB, "dedup", jobs.Dedup)
+	if err != nil {
+		log.Fatalf("Failed to start dedup: %v", err)
+	}
+	log.Printf("Dedup job #%d %s: %s", job.ID, job.Status, job.Message)
+	if job.Status != models.JobSucceeded {
+		os.Exit(1)
 	}
-
-	log.Println("Deduplication complete.")
 }
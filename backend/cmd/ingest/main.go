@@ -4,13 +4,15 @@ import (
 	"dramabang/database"
 	"dramabang/models"
 	"dramabang/services/adapter"
+	"dramabang/services/credentials"
+	"dramabang/services/jobs"
 	"log"
 	"os"
 
 	"github.com/joho/godotenv"
-	"gorm.io/gorm/clause"
 )
 
+// Standalone ingest; the server runs the same task via /api/admin/jobs
 func main() {
 	// Load .env if exists
 	if err := godotenv.Load("../../.env"); err != nil {
@@ -23,72 +25,26 @@ func main() {
 
 	// Ensure migrations
 	models.MigrateDramas(database.DB)
+	models.MigrateJobs(database.DB)
+	models.MigrateSnapshots(database.DB)
+	models.MigrateGenres(database.DB)
+	models.MigrateReviews(database.DB)
+	models.MigrateSearch(database.DB)
 	log.Println("✅ Database migrations complete")
 
-	// Initialize providers
-	providers := []adapter.Provider{
-		adapter.NewMeloloProvider(),
-		adapter.NewNetshortProvider(),
-		// Dramabox is skipped due to API issues
+	// Load provider credentials (Netshort needs a bearer token)
+	credStore, err := credentials.Init(database.DB)
+	if err != nil {
+		log.Fatalf("❌ Failed to load credential vault: %v", err)
 	}
+	adapter.Credentials = credStore
 
-	totalDramas := 0
-
-	for _, provider := range providers {
-		providerName := provider.GetID()
-		log.Printf("📥 Fetching from %s...", providerName)
-
-		// Get trending dramas
-		dramas, err := provider.GetTrending()
-		if err != nil {
-			log.Printf("⚠️  Error fetching from %s: %v", providerName, err)
-			continue
-		}
-
-		if len(dramas) == 0 {
-			log.Printf("⚠️  No dramas found from %s", providerName)
-			continue
-		}
-
-		log.Printf("📦 Received %d dramas from %s", len(dramas), providerName)
-
-		// Upsert dramas into database
-		for _, drama := range dramas {
-			if err := database.DB.Clauses(clause.OnConflict{
-				Columns:   []clause.Column{{Name: "book_id"}},
-				DoUpdates: clause.AssignmentColumns([]string{"judul", "cover", "deskripsi", "total_episode", "genre"}),
-			}).Create(&drama).Error; err != nil {
-				log.Printf("❌ Error saving drama %s: %v", drama.BookID, err)
-			}
-		}
-
-		log.Printf("✅ Saved %d dramas from %s", len(dramas), providerName)
-		totalDramas += len(dramas)
-
-		// Also fetch latest (with pagination) for more content
-		log.Printf("📥 Fetching latest from %s (page 1)...", providerName)
-		latestDramas, err := provider.GetLatest(1)
-		if err == nil && len(latestDramas) > 0 {
-			for _, drama := range latestDramas {
-				database.DB.Clauses(clause.OnConflict{
-					Columns:   []clause.Column{{Name: "book_id"}},
-					DoUpdates: clause.AssignmentColumns([]string{"judul", "cover", "deskripsi", "total_episode", "genre"}),
-				}).Create(&drama)
-			}
-			log.Printf("✅ Saved %d latest dramas from %s", len(latestDramas), providerName)
-			totalDramas += len(latestDramas)
-		}
+	job, err := jobs.RunStandalone(database.DB, "ingest", jobs.Ingest(adapter.NewManager()))
+	if err != nil {
+		log.Fatalf("❌ Failed to start ingest: %v", err)
 	}
-
-	log.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
-	if totalDramas > 0 {
-		log.Printf("🎉 Ingestion completed successfully!")
-		log.Printf("📊 Total dramas inserted/updated: %d", totalDramas)
-		log.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
-		os.Exit(0)
-	} else {
-		log.Println("⚠️  No dramas were ingested. Check provider APIs.")
-		log.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	log.Printf("Ingest job #%d %s: %s", job.ID, job.Status, job.Message)
+	if job.Status != models.JobSucceeded {
 		os.Exit(1)
 	}
 }
@@ -3,11 +3,12 @@ package handlers
 import (
 	"dramabang/database"
 	"dramabang/models"
+	"dramabang/services/titles"
 	"encoding/json"
 	"fmt"
 	"os"
-	"os/exec"
 	"strconv"
+	"strings"
 
 	"github.com/gofiber/fiber/v2"
 	"golang.org/x/crypto/bcrypt"
@@ -129,9 +130,17 @@ func GetAdminDramas(c *fiber.Ctx) error {
 	genre := c.Query("genre")
 	sortBy := c.Query("sort")
 	featured := c.Query("featured")
+	availability := c.Query("availability") // "available", "unstable" or "unavailable"
+	minEpisodes := c.QueryInt("min_episodes")
+	maxEpisodes := c.QueryInt("max_episodes")
+	seriesStatus := c.Query("series_status") // "ongoing" or "completed"
+	language := c.Query("language")
+	dubbed := c.Query("dubbed")
+	tag := c.Query("tag") // Exact genre slug; "genre" still does a substring match
+	searchKey := titles.Key(search)
 
 	if search != "" {
-		query = query.Where("judul LIKE ?", "%"+search+"%")
+		query = query.Where("judul LIKE ? OR title_key LIKE ?", "%"+search+"%", "%"+searchKey+"%")
 	}
 	if genre != "" {
 		query = query.Where("genre LIKE ?", "%"+genre+"%")
@@ -139,6 +148,29 @@ func GetAdminDramas(c *fiber.Ctx) error {
 	if featured == "true" {
 		query = query.Where("is_featured = ?", true)
 	}
+	if availability == "available" {
+		query = query.Where("availability IS NULL OR availability = ''")
+	} else if availability != "" {
+		query = query.Where("availability = ?", availability)
+	}
+	if minEpisodes > 0 {
+		query = query.Where("episode_count >= ?", minEpisodes)
+	}
+	if maxEpisodes > 0 {
+		query = query.Where("episode_count <= ?", maxEpisodes)
+	}
+	if seriesStatus != "" {
+		query = query.Where("series_status = ?", seriesStatus)
+	}
+	if language != "" {
+		query = query.Where("language = ?", language)
+	}
+	if dubbed == "true" || dubbed == "false" {
+		query = query.Where("dubbed = ?", dubbed == "true")
+	}
+	if tag != "" {
+		query = models.WithGenre(query, tag)
+	}
 
 	// Count
 	query.Count(&total)
@@ -146,7 +178,7 @@ func GetAdminDramas(c *fiber.Ctx) error {
 	// Fetch Data
 	dataQuery := database.DB.Model(&models.Drama{})
 	if search != "" {
-		dataQuery = dataQuery.Where("judul LIKE ?", "%"+search+"%")
+		dataQuery = dataQuery.Where("judul LIKE ? OR title_key LIKE ?", "%"+search+"%", "%"+searchKey+"%")
 	}
 	if genre != "" {
 		dataQuery = dataQuery.Where("genre LIKE ?", "%"+genre+"%")
@@ -154,6 +186,29 @@ func GetAdminDramas(c *fiber.Ctx) error {
 	if featured == "true" {
 		dataQuery = dataQuery.Where("is_featured = ?", true)
 	}
+	if availability == "available" {
+		dataQuery = dataQuery.Where("availability IS NULL OR availability = ''")
+	} else if availability != "" {
+		dataQuery = dataQuery.Where("availability = ?", availability)
+	}
+	if minEpisodes > 0 {
+		dataQuery = dataQuery.Where("episode_count >= ?", minEpisodes)
+	}
+	if maxEpisodes > 0 {
+		dataQuery = dataQuery.Where("episode_count <= ?", maxEpisodes)
+	}
+	if seriesStatus != "" {
+		dataQuery = dataQuery.Where("series_status = ?", seriesStatus)
+	}
+	if language != "" {
+		dataQuery = dataQuery.Where("language = ?", language)
+	}
+	if dubbed == "true" || dubbed == "false" {
+		dataQuery = dataQuery.Where("dubbed = ?", dubbed == "true")
+	}
+	if tag != "" {
+		dataQuery = models.WithGenre(dataQuery, tag)
+	}
 
 	// Sort
 	switch sortBy {
@@ -163,11 +218,18 @@ func GetAdminDramas(c *fiber.Ctx) error {
 		dataQuery = dataQuery.Order("judul asc")
 	case "title_desc":
 		dataQuery = dataQuery.Order("judul desc")
+	case "episodes_asc":
+		dataQuery = dataQuery.Order("episode_count asc")
+	case "episodes_desc":
+		dataQuery = dataQuery.Order("episode_count desc")
+	case "likes":
+		dataQuery = dataQuery.Order("likes_count desc")
 	default:
 		dataQuery = dataQuery.Order("book_id desc") // newest
 	}
 
 	dataQuery.Limit(limit).Offset(offset).Find(&dramas)
+	models.LoadGenres(database.DB, dramas)
 
 	return c.JSON(fiber.Map{
 		"status": "success",
@@ -197,10 +259,23 @@ func UpdateDrama(c *fiber.Ctx) error {
 	drama.Cover = input.Cover
 	drama.TotalEpisode = input.TotalEpisode
 	drama.Deskripsi = input.Deskripsi
-	drama.Genre = input.Genre
+	genreChanged := input.Genre != drama.Genre
 
 	database.DB.Save(&drama)
 
+	// The genre text is edited as a comma list of manual genres, which locks them
+	if genreChanged {
+		var names []string
+		for _, name := range strings.Split(input.Genre, ",") {
+			names = append(names, strings.TrimSpace(name))
+		}
+		if err := lockGenres(bookId, names); err != nil {
+			return c.Status(500).JSON(fiber.Map{"status": "error", "message": "Failed to save genres"})
+		}
+		database.DB.First(&drama, "book_id = ?", bookId)
+	}
+	drama.Genres = models.DramaGenres(database.DB, bookId)
+
 	return c.JSON(fiber.Map{"status": "success", "message": "Drama updated", "data": drama})
 }
 
@@ -208,6 +283,7 @@ func UpdateDrama(c *fiber.Ctx) error {
 func DeleteDrama(c *fiber.Ctx) error {
 	bookId := c.Params("id")
 	result := database.DB.Delete(&models.Drama{}, "book_id = ?", bookId)
+	database.DB.Where("book_id = ?", bookId).Delete(&models.DramaGenre{})
 
 	if result.Error != nil {
 		return c.Status(500).JSON(fiber.Map{"status": "error", "message": "Failed to delete"})
@@ -216,52 +292,14 @@ func DeleteDrama(c *fiber.Ctx) error {
 	return c.JSON(fiber.Map{"status": "success", "message": "Drama deleted"})
 }
 
-// TriggerIngest runs the ingest script
+// TriggerIngest starts an ingest job
 func TriggerIngest(c *fiber.Ctx) error {
-	models.LogInfo(database.DB, "Ingest Process Triggered")
-	// Run in background
-	go func() {
-		cmd := exec.Command("./ingest")
-		output, err := cmd.CombinedOutput()
-		if err != nil {
-			fmt.Println("Ingest Error:", err)
-			models.LogError(database.DB, fmt.Sprintf("Ingest Failed: %v", err))
-			return
-		}
-		// Log explicit success or output summary?
-		// Truncate output if too long
-		outStr := string(output)
-		if len(outStr) > 100 {
-			outStr = outStr[:100] + "..."
-		}
-		fmt.Println("Ingest Output:", string(output))
-		models.LogSuccess(database.DB, "Ingest Finished: "+outStr)
-	}()
-
-	return c.JSON(fiber.Map{"status": "success", "message": "Ingest process started in background"})
+	return triggerJob(c, "ingest")
 }
 
-// TriggerDedup runs the dedup script
+// TriggerDedup starts a dedup job
 func TriggerDedup(c *fiber.Ctx) error {
-	models.LogInfo(database.DB, "Deduplication Process Triggered")
-	// Run in background
-	go func() {
-		cmd := exec.Command("./dedup")
-		output, err := cmd.CombinedOutput()
-		if err != nil {
-			fmt.Println("Dedup Error:", err)
-			models.LogError(database.DB, fmt.Sprintf("Dedup Failed: %v", err))
-			return
-		}
-		outStr := string(output)
-		if len(outStr) > 100 {
-			outStr = outStr[:100] + "..."
-		}
-		fmt.Println("Dedup Output:", string(output))
-		models.LogSuccess(database.DB, "Dedup Finished: "+outStr)
-	}()
-
-	return c.JSON(fiber.Map{"status": "success", "message": "Deduplication process started in background"})
+	return triggerJob(c, "dedup")
 }
 
 // GetSystemLogs retrieves recent logs
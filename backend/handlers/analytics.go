@@ -0,0 +1,251 @@
+package handlers
+
+import (
+	"dramabang/database"
+	"dramabang/models"
+	"dramabang/services/adapter"
+	"dramabang/services/suggest"
+	"dramabang/services/titles"
+	"dramabang/utils"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+const (
+	// popularWindow is how far back popular searches look
+	popularWindow = 7 * 24 * time.Hour
+	// popularTTL is how long the public popular list is cached
+	popularTTL = 10 * time.Minute
+	// popularMinSessions keeps one client from pushing a query into the chip row
+	popularMinSessions = 2
+	// trendingMinSessions is the least recent interest for a query to trend
+	trendingMinSessions = 3
+)
+
+var popularCache struct {
+	sync.Mutex
+	at   time.Time
+	data []models.QueryStat
+}
+
+// searchSession identifies the searcher without storing who they are: the user
+// ID when signed in, otherwise IP and user agent, hashed with a random daily salt
+func searchSession(c *fiber.Ctx) string {
+	id := c.IP() + "|" + c.Get("User-Agent")
+	if userID, ok := optionalUserID(c); ok {
+		id = fmt.Sprint("user:", userID)
+	}
+	return utils.AnonymousID(id)
+}
+
+// logSearch stores one search in the background so it doesn't add latency
+func logSearch(c *fiber.Ctx, q string, results int, source, corrected string, health adapter.SearchHealth, local int, started time.Time) {
+	providers := make(map[string]int, len(health.Results)+1)
+	for id, n := range health.Results {
+		providers[id] = n
+	}
+	providers["local"] = local
+
+	entry := models.SearchLog{
+		Query:     titles.Key(q),
+		Raw:       q,
+		Results:   results,
+		Providers: providers,
+		Source:    source,
+		Corrected: corrected,
+		LatencyMs: time.Since(started).Milliseconds(),
+		Session:   searchSession(c),
+	}
+	go func() {
+		if err := database.DB.Create(&entry).Error; err != nil {
+			fmt.Println("Failed to log search:", err)
+		}
+	}()
+}
+
+// SeedPopularQueries loads recent successful queries into the autocomplete
+// counter so suggestions survive restarts
+func SeedPopularQueries() {
+	for _, s := range models.TopQueries(database.DB, time.Now().Add(-popularWindow), 500, false, true) {
+		suggest.Default.SeedQuery(s.Query, int(s.Searches))
+	}
+}
+
+// GetPopularSearches lists the most searched queries of the last week that
+// returned results, for the "popular searches" chips (?limit=)
+func GetPopularSearches(c *fiber.Ctx) error {
+	limit := c.QueryInt("limit", 10)
+	if limit < 1 || limit > 30 {
+		limit = 10
+	}
+
+	popularCache.Lock()
+	defer popularCache.Unlock()
+	if popularCache.data == nil || time.Since(popularCache.at) > popularTTL {
+		popular := []models.QueryStat{}
+		for _, s := range models.TopQueries(database.DB, time.Now().Add(-popularWindow), 30, false, true) {
+			if s.Sessions >= popularMinSessions {
+				popular = append(popular, s)
+			}
+		}
+		popularCache.data, popularCache.at = popular, time.Now()
+	}
+
+	queries := make([]string, 0, limit)
+	for _, s := range popularCache.data {
+		if len(queries) == limit {
+			break
+		}
+		queries = append(queries, s.Query)
+	}
+	return c.JSON(fiber.Map{"status": "success", "data": queries})
+}
+
+// TrendingQuery compares the last day's interest in a query with its daily average before that
+type TrendingQuery struct {
+	Query    string  `json:"query"`
+	Recent   int64   `json:"recent_sessions"`
+	Baseline float64 `json:"baseline_sessions"` // Daily average over the rest of the window
+	Score    float64 `json:"score"`
+}
+
+// ProviderSearchStat summarizes how one source contributed to searches
+type ProviderSearchStat struct {
+	Provider   string  `json:"provider"`
+	Answered   int64   `json:"answered"`
+	AvgResults float64 `json:"avg_results"`
+	ZeroRate   float64 `json:"zero_rate"`
+}
+
+// GetSearchAnalytics reports search volume, top, trending and zero-result
+// queries and per-provider result counts (?days=7, ?limit=20)
+func GetSearchAnalytics(c *fiber.Ctx) error {
+	days := c.QueryInt("days", 7)
+	if days < 1 || days > 90 {
+		days = 7
+	}
+	limit := c.QueryInt("limit", 20)
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+	now := time.Now()
+	since := now.AddDate(0, 0, -days)
+	db := database.DB
+
+	var summary struct {
+		Searches   int64   `json:"searches"`
+		Sessions   int64   `json:"sessions"`
+		Queries    int64   `json:"unique_queries"`
+		ZeroRate   float64 `json:"zero_rate"`
+		AvgLatency float64 `json:"avg_latency_ms"`
+	}
+	db.Model(&models.SearchLog{}).
+		Select("count(*) as searches, count(distinct session) as sessions, count(distinct query) as queries, "+
+			"coalesce(avg(case when results = 0 then 1.0 else 0.0 end), 0) as zero_rate, coalesce(avg(latency_ms), 0) as avg_latency").
+		Where("created_at >= ?", since).
+		Scan(&summary)
+
+	var sources []struct {
+		Source   string `json:"source"`
+		Searches int64  `json:"searches"`
+	}
+	db.Model(&models.SearchLog{}).Select("source, count(*) as searches").
+		Where("created_at >= ?", since).Group("source").Order("searches desc").Scan(&sources)
+
+	return c.JSON(fiber.Map{
+		"status": "success",
+		"data": fiber.Map{
+			"days":         days,
+			"summary":      summary,
+			"sources":      sources,
+			"providers":    providerSearchStats(db, since),
+			"top":          models.TopQueries(db, since, limit, false, false),
+			"zero_results": models.TopQueries(db, since, limit, true, false),
+			"trending":     trendingQueries(db, now, days, limit),
+		},
+	})
+}
+
+// trendingQueries ranks queries whose last 24h sessions most exceed their
+// daily average over the rest of the window
+func trendingQueries(db *gorm.DB, now time.Time, days, limit int) []TrendingQuery {
+	dayAgo := now.Add(-24 * time.Hour)
+	recent := models.QuerySessions(db, dayAgo, now)
+	var before map[string]int64
+	baseDays := float64(days - 1)
+	if baseDays > 0 {
+		before = models.QuerySessions(db, now.AddDate(0, 0, -days), dayAgo)
+	}
+
+	out := make([]TrendingQuery, 0)
+	for q, n := range recent {
+		if n < trendingMinSessions {
+			continue
+		}
+		baseline := 0.0
+		if baseDays > 0 {
+			baseline = float64(before[q]) / baseDays
+		}
+		score := math.Round(float64(n)/(baseline+1)*100) / 100
+		if score <= 1 {
+			continue
+		}
+		out = append(out, TrendingQuery{Query: q, Recent: n, Baseline: math.Round(baseline*100) / 100, Score: score})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Score != out[j].Score {
+			return out[i].Score > out[j].Score
+		}
+		return out[i].Query < out[j].Query
+	})
+	if len(out) > limit {
+		out = out[:limit]
+	}
+	return out
+}
+
+// providerSearchStats totals the per-provider result counts stored on each log
+func providerSearchStats(db *gorm.DB, since time.Time) []ProviderSearchStat {
+	type acc struct{ answered, results, zero int64 }
+	totals := make(map[string]*acc)
+
+	var batch []models.SearchLog
+	db.Model(&models.SearchLog{}).Select("id, providers").Where("created_at >= ?", since).
+		FindInBatches(&batch, 1000, func(tx *gorm.DB, _ int) error {
+			for _, l := range batch {
+				for id, n := range l.Providers {
+					a := totals[id]
+					if a == nil {
+						a = &acc{}
+						totals[id] = a
+					}
+					a.answered++
+					a.results += int64(n)
+					if n == 0 {
+						a.zero++
+					}
+				}
+			}
+			return nil
+		})
+
+	out := make([]ProviderSearchStat, 0, len(totals))
+	for id, a := range totals {
+		out = append(out, ProviderSearchStat{
+			Provider:   id,
+			Answered:   a.answered,
+			AvgResults: math.Round(float64(a.results)/float64(a.answered)*100) / 100,
+			ZeroRate:   math.Round(float64(a.zero)/float64(a.answered)*100) / 100,
+		})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Answered > out[j].Answered || (out[i].Answered == out[j].Answered && out[i].Provider < out[j].Provider)
+	})
+	return out
+}
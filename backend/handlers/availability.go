@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"dramabang/database"
+	"dramabang/models"
+	"dramabang/services/adapter"
+	"time"
+
+	"github.com/patrickmn/go-cache"
+)
+
+// maxAlternates is how many other sources are suggested for a delisted drama
+const maxAlternates = 3
+
+// seenInterval is how often a successful detail view may refresh last_seen_at
+const seenInterval = time.Hour
+
+// recentlySeen holds dramas whose successful lookup was recorded within seenInterval
+var recentlySeen = cache.New(seenInterval, 10*time.Minute)
+
+// hideUnavailable drops dramas that have been delisted upstream
+func hideUnavailable(dramas []models.Drama) []models.Drama {
+	ids := make([]string, len(dramas))
+	for i, d := range dramas {
+		ids[i] = d.BookID
+	}
+	hidden := models.UnavailableIDs(database.DB, ids)
+	if len(hidden) == 0 {
+		return dramas
+	}
+
+	visible := make([]models.Drama, 0, len(dramas))
+	for _, d := range dramas {
+		if !hidden[d.BookID] {
+			visible = append(visible, d)
+		}
+	}
+	return visible
+}
+
+// recordLookup updates availability after a live detail lookup. Successes are
+// written at most once per seenInterval per drama, or right away when the drama
+// is flagged. Only a not-found answer counts against it; providers without a
+// detail endpoint are left to the ingest job.
+func recordLookup(bookID string, err error) {
+	if !AdapterManager.HasDetail(bookID) {
+		return
+	}
+	if err == nil {
+		if _, seen := recentlySeen.Get(bookID); seen {
+			return
+		}
+		recentlySeen.SetDefault(bookID, true)
+		models.MarkSeenIfStale(database.DB, bookID, seenInterval)
+	} else if adapter.IsNotFound(err) {
+		recentlySeen.Delete(bookID)
+		models.MarkFailure(database.DB, bookID)
+	}
+}
+
+// alternatesFor suggests other sources for a stored drama, if any
+func alternatesFor(bookID string) []models.Drama {
+	var drama models.Drama
+	if err := database.DB.Where("book_id = ?", bookID).First(&drama).Error; err != nil {
+		return nil
+	}
+	return models.FindAlternates(database.DB, drama, maxAlternates)
+}
@@ -0,0 +1,321 @@
+package handlers
+
+import (
+	"dramabang/database"
+	"dramabang/models"
+	"dramabang/services/adapter"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+const (
+	// heroFallbackSize is how many trending items fill the hero without banners
+	heroFallbackSize = 5
+	// heroMaxSlots caps banners plus featured dramas
+	heroMaxSlots = 10
+)
+
+// HeroItem is a drama-shaped hero slide with its banner fields
+type HeroItem struct {
+	models.Drama
+	BannerID uint   `json:"banner_id,omitempty"`
+	Link     string `json:"link"`                // Where the slide leads
+	ClickURL string `json:"click_url,omitempty"` // POST here to count a click on the slide
+}
+
+// bannerInput is the body for creating or replacing a banner
+type bannerInput struct {
+	BookID   string     `json:"bookId"`
+	Title    string     `json:"title"`
+	Subtitle string     `json:"subtitle"`
+	Image    string     `json:"image"`
+	Link     string     `json:"link"`
+	Position int        `json:"position"`
+	Locales  []string   `json:"locales"`
+	StartsAt *time.Time `json:"starts_at"`
+	EndsAt   *time.Time `json:"ends_at"`
+	Enabled  *bool      `json:"enabled"`
+}
+
+// GetHero returns active banners for the locale, then dramas an admin marked
+// featured, falling back to the top trending items when neither exists
+func GetHero(c *fiber.Ctx) error {
+	items, source, err := heroItems(requestLocale(c))
+	if err != nil {
+		return c.Status(502).JSON(fiber.Map{"error": "Failed to fetch hero data"})
+	}
+
+	return c.JSON(fiber.Map{
+		"status": "success",
+		"type":   "hero",
+		"source": source,
+		"data":   items,
+	})
+}
+
+// heroItems builds the hero slides and reports whether they came from banners or trending
+func heroItems(locale string) ([]HeroItem, string, error) {
+	items := make([]HeroItem, 0, heroMaxSlots)
+	seen := make(map[string]bool)
+
+	for _, b := range models.ActiveBanners(database.DB, locale, time.Now()) {
+		if len(items) == heroMaxSlots {
+			break
+		}
+		item, ok := bannerItem(locale, b)
+		if !ok {
+			continue
+		}
+		if item.BookID != "" {
+			seen[item.BookID] = true
+		}
+		items = append(items, item)
+	}
+
+	var featured []models.Drama
+	database.DB.Where("is_featured = ?", true).
+		Where("availability IS NULL OR availability <> ?", models.AvailabilityUnavailable).
+		Order("book_id").Limit(heroMaxSlots).Find(&featured)
+	for _, d := range featured {
+		if len(items) == heroMaxSlots {
+			break
+		}
+		if !seen[d.BookID] {
+			seen[d.BookID] = true
+			items = append(items, HeroItem{Drama: d, Link: "/detail/" + d.BookID})
+		}
+	}
+
+	if len(items) > 0 {
+		return items, "banners", nil
+	}
+	dramas, err := AdapterManager.GetTrending(locale)
+	if err != nil {
+		return nil, "", err
+	}
+	dramas = hideUnavailable(dramas)
+	for i := 0; i < len(dramas) && i < heroFallbackSize; i++ {
+		d := dramas[i]
+		d.IsFeatured = true
+		items = append(items, HeroItem{Drama: d, Link: "/detail/" + d.BookID})
+	}
+	return items, "trending", nil
+}
+
+// bannerItem builds a slide, skipping drama banners whose drama is gone
+func bannerItem(locale string, b models.Banner) (HeroItem, bool) {
+	var d models.Drama
+	if b.BookID != "" {
+		err := database.DB.Where("book_id = ?", b.BookID).First(&d).Error
+		if err == gorm.ErrRecordNotFound {
+			fetched, _, ferr := AdapterManager.GetDetail(locale, b.BookID)
+			if ferr != nil || fetched == nil {
+				return HeroItem{}, false
+			}
+			d = *fetched
+			d.Episodes = nil
+		} else if err != nil || d.Availability == models.AvailabilityUnavailable {
+			return HeroItem{}, false
+		}
+	}
+
+	if b.Title != "" {
+		d.Judul = b.Title
+	}
+	if b.Subtitle != "" {
+		d.Deskripsi = b.Subtitle
+	}
+	if b.Image != "" {
+		d.Cover = b.Image
+	}
+	d.IsFeatured = true
+
+	link := b.Link
+	if link == "" {
+		link = "/detail/" + b.BookID
+	}
+	return HeroItem{
+		Drama:    d,
+		BannerID: b.ID,
+		Link:     link,
+		ClickURL: fmt.Sprintf("/api/hero/%d/click", b.ID),
+	}, true
+}
+
+// TrackBannerClick counts a click on a banner. It is POST-only so link
+// prefetchers and crawlers following the slide don't inflate the count
+func TrackBannerClick(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"status": "error", "message": "Invalid banner id"})
+	}
+	var banner models.Banner
+	if err := database.DB.First(&banner, id).Error; err != nil {
+		return c.Status(404).JSON(fiber.Map{"status": "error", "message": "Banner not found"})
+	}
+
+	now := time.Now()
+	database.DB.Model(&banner).UpdateColumns(map[string]interface{}{
+		"clicks":          gorm.Expr("clicks + 1"),
+		"last_clicked_at": now,
+	})
+
+	target := banner.Link
+	if target == "" {
+		target = "/detail/" + banner.BookID
+	}
+	return c.JSON(fiber.Map{"status": "success", "link": target})
+}
+
+// GetBanners lists every banner with its schedule status (?status=active|scheduled|expired|disabled)
+func GetBanners(c *fiber.Ctx) error {
+	var banners []models.Banner
+	if err := database.DB.Order("position, id").Find(&banners).Error; err != nil {
+		return c.Status(500).JSON(fiber.Map{"status": "error", "message": "Failed to fetch banners"})
+	}
+
+	now := time.Now()
+	filter := c.Query("status")
+	type bannerView struct {
+		models.Banner
+		Status string `json:"status"`
+	}
+	list := make([]bannerView, 0, len(banners))
+	for _, b := range banners {
+		status := b.Status(now)
+		if filter == "" || filter == status {
+			list = append(list, bannerView{Banner: b, Status: status})
+		}
+	}
+	return c.JSON(fiber.Map{"status": "success", "data": list})
+}
+
+// CreateBanner adds a hero banner; it is enabled unless the body says otherwise
+func CreateBanner(c *fiber.Ctx) error {
+	var input bannerInput
+	if err := c.BodyParser(&input); err != nil {
+		return c.Status(400).JSON(fiber.Map{"status": "error", "message": "Invalid input"})
+	}
+	banner := models.Banner{Enabled: true}
+	if msg := applyBannerInput(&banner, input); msg != "" {
+		return c.Status(400).JSON(fiber.Map{"status": "error", "message": msg})
+	}
+	if err := database.DB.Create(&banner).Error; err != nil {
+		return c.Status(500).JSON(fiber.Map{"status": "error", "message": "Failed to create banner"})
+	}
+
+	models.LogInfo(database.DB, fmt.Sprintf("Banner #%d created", banner.ID))
+	return c.JSON(fiber.Map{"status": "success", "data": banner})
+}
+
+// UpdateBanner replaces a banner's content and schedule; click counts are kept
+func UpdateBanner(c *fiber.Ctx) error {
+	var banner models.Banner
+	if err := database.DB.First(&banner, c.Params("id")).Error; err != nil {
+		return c.Status(404).JSON(fiber.Map{"status": "error", "message": "Banner not found"})
+	}
+	var input bannerInput
+	if err := c.BodyParser(&input); err != nil {
+		return c.Status(400).JSON(fiber.Map{"status": "error", "message": "Invalid input"})
+	}
+	if msg := applyBannerInput(&banner, input); msg != "" {
+		return c.Status(400).JSON(fiber.Map{"status": "error", "message": msg})
+	}
+	if err := database.DB.Save(&banner).Error; err != nil {
+		return c.Status(500).JSON(fiber.Map{"status": "error", "message": "Failed to update banner"})
+	}
+
+	models.LogInfo(database.DB, fmt.Sprintf("Banner #%d updated", banner.ID))
+	return c.JSON(fiber.Map{"status": "success", "data": banner})
+}
+
+// DeleteBanner removes a banner
+func DeleteBanner(c *fiber.Ctx) error {
+	res := database.DB.Delete(&models.Banner{}, c.Params("id"))
+	if res.Error != nil {
+		return c.Status(500).JSON(fiber.Map{"status": "error", "message": "Failed to delete banner"})
+	}
+	if res.RowsAffected == 0 {
+		return c.Status(404).JSON(fiber.Map{"status": "error", "message": "Banner not found"})
+	}
+
+	models.LogInfo(database.DB, fmt.Sprintf("Banner #%s deleted", c.Params("id")))
+	return c.JSON(fiber.Map{"status": "success", "message": "Banner deleted"})
+}
+
+// ReorderBanners sets positions from an ordered list of IDs ({"ids": [3, 1, 2]})
+func ReorderBanners(c *fiber.Ctx) error {
+	var input struct {
+		IDs []uint `json:"ids"`
+	}
+	if err := c.BodyParser(&input); err != nil || len(input.IDs) == 0 {
+		return c.Status(400).JSON(fiber.Map{"status": "error", "message": "ids is required"})
+	}
+
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		for i, id := range input.IDs {
+			if err := tx.Model(&models.Banner{}).Where("id = ?", id).Update("position", i).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"status": "error", "message": "Failed to reorder banners"})
+	}
+	return GetBanners(c)
+}
+
+// applyBannerInput validates input and copies it onto banner, returning an error message
+func applyBannerInput(banner *models.Banner, input bannerInput) string {
+	input.BookID = strings.TrimSpace(input.BookID)
+	input.Image = strings.TrimSpace(input.Image)
+	input.Link = strings.TrimSpace(input.Link)
+
+	if input.BookID == "" && (input.Image == "" || input.Link == "") {
+		return "A banner needs a bookId, or an image and a link"
+	}
+	if input.BookID == "" && input.Title == "" {
+		return "Custom banners need a title"
+	}
+	if input.BookID != "" {
+		var count int64
+		database.DB.Model(&models.Drama{}).Where("book_id = ?", input.BookID).Count(&count)
+		if count == 0 {
+			return "Drama not found in the catalog"
+		}
+	}
+	if input.Link != "" && !strings.HasPrefix(input.Link, "/") &&
+		!strings.HasPrefix(input.Link, "https://") && !strings.HasPrefix(input.Link, "http://") {
+		return "Link must be a site path or an http(s) URL"
+	}
+	if input.StartsAt != nil && input.EndsAt != nil && !input.EndsAt.After(*input.StartsAt) {
+		return "ends_at must be after starts_at"
+	}
+	locales := make([]string, 0, len(input.Locales))
+	for _, l := range input.Locales {
+		normalized := adapter.NormalizeLocale(l)
+		if !strings.EqualFold(strings.TrimSpace(l), normalized) {
+			return "Unsupported locale: " + l
+		}
+		locales = append(locales, normalized)
+	}
+
+	banner.BookID = input.BookID
+	banner.Title = strings.TrimSpace(input.Title)
+	banner.Subtitle = strings.TrimSpace(input.Subtitle)
+	banner.Image = input.Image
+	banner.Link = input.Link
+	banner.Position = input.Position
+	banner.Locales = locales
+	banner.StartsAt = input.StartsAt
+	banner.EndsAt = input.EndsAt
+	if input.Enabled != nil {
+		banner.Enabled = *input.Enabled
+	}
+	return ""
+}
@@ -31,7 +31,7 @@ func AddBookmark(c *fiber.Ctx) error {
 	var drama models.Drama
 	if err := database.DB.Where("book_id = ?", req.BookID).First(&drama).Error; err != nil {
 		// Not found, fetch from Universal Adapter
-		fetchedDrama, _, err := AdapterManager.GetDetail(req.BookID)
+		fetchedDrama, _, err := AdapterManager.GetDetail(requestLocale(c), req.BookID)
 		if err != nil {
 			return c.Status(404).JSON(fiber.Map{"status": "error", "message": "Drama not found"})
 		}
@@ -89,6 +89,14 @@ func GetBookmarks(c *fiber.Ctx) error {
 	// Lazy Ingest check (redundant if AddBookmark always ensures uniqueness, but safe for old data/migrations)
 	// We'll skip lazy ingest on GET for speed, relying on Add/History to populate Drama table.
 
+	// Flag delisted dramas and suggest other sources
+	for i, b := range bookmarks {
+		if b.Drama.IsUnavailable() {
+			bookmarks[i].Unavailable = true
+			bookmarks[i].Alternates = models.FindAlternates(database.DB, b.Drama, maxAlternates)
+		}
+	}
+
 	return c.JSON(fiber.Map{
 		"status": "success",
 		"data":   bookmarks,
@@ -0,0 +1,199 @@
+package handlers
+
+import (
+	"dramabang/database"
+	"dramabang/models"
+	"sort"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// categorySorts maps ?sort= values to catalog ordering
+var categorySorts = map[string]string{
+	"newest":   "created_at desc, book_id desc",
+	"popular":  "(likes_count + views_count) desc, book_id desc",
+	"episodes": "episode_count desc, book_id desc",
+}
+
+// Category is one genre in the public browse list
+type Category struct {
+	Slug          string `json:"slug"`
+	Name          string `json:"name"`
+	Count         int64  `json:"count"`
+	Popularity    int64  `json:"popularity"`     // Summed likes and views
+	TotalEpisodes int64  `json:"total_episodes"` // Summed episode counts
+	Cover         string `json:"cover,omitempty"`
+	latest        string
+}
+
+// GetCategories lists visible genres with drama counts (?sort=popular|newest|episodes|name, ?lang=)
+func GetCategories(c *fiber.Ctx) error {
+	locale := requestLocale(c)
+
+	type stat struct {
+		GenreID    uint
+		Count      int64
+		Popularity int64
+		Episodes   int64
+		Latest     string
+	}
+	var stats []stat
+	database.DB.Table("drama_genres").
+		Select("drama_genres.genre_id, count(*) as count, "+
+			"coalesce(sum(dramas.likes_count + dramas.views_count), 0) as popularity, "+
+			"coalesce(sum(dramas.episode_count), 0) as episodes, max(dramas.created_at) as latest").
+		Joins("JOIN dramas ON dramas.book_id = drama_genres.book_id").
+		Where("dramas.availability IS NULL OR dramas.availability <> ?", models.AvailabilityUnavailable).
+		Group("drama_genres.genre_id").
+		Scan(&stats)
+	byID := make(map[uint]stat, len(stats))
+	for _, s := range stats {
+		byID[s.GenreID] = s
+	}
+
+	var genres []models.Genre
+	database.DB.Where("hidden = ?", false).Find(&genres)
+
+	categories := make([]Category, 0, len(genres))
+	for _, g := range genres {
+		s, ok := byID[g.ID]
+		if !ok {
+			continue
+		}
+		categories = append(categories, Category{
+			Slug:          g.Slug,
+			Name:          g.DisplayName(locale),
+			Count:         s.Count,
+			Popularity:    s.Popularity,
+			TotalEpisodes: s.Episodes,
+			latest:        s.Latest,
+		})
+	}
+
+	sortBy := c.Query("sort", "popular")
+	sort.SliceStable(categories, func(i, j int) bool {
+		a, b := categories[i], categories[j]
+		switch sortBy {
+		case "name":
+			return a.Name < b.Name
+		case "newest":
+			return a.latest > b.latest
+		case "episodes":
+			return a.TotalEpisodes > b.TotalEpisodes
+		}
+		if a.Popularity != b.Popularity {
+			return a.Popularity > b.Popularity
+		}
+		return a.Count > b.Count
+	})
+
+	// One representative cover per category for browse tiles
+	for i := range categories {
+		var cover string
+		models.WithGenre(database.DB.Model(&models.Drama{}), categories[i].Slug).
+			Where("cover <> ''").Order(categorySorts["popular"]).Limit(1).Pluck("cover", &cover)
+		categories[i].Cover = cover
+	}
+
+	return c.JSON(fiber.Map{"status": "success", "data": categories})
+}
+
+// GetCategory returns one genre's dramas from the local catalog (?page=, ?limit=,
+// ?sort=, ?dubbed=). Page 1 also lists matching upstream items not yet in the
+// catalog under "upstream"; they are not counted in total.
+func GetCategory(c *fiber.Ctx) error {
+	slug := c.Params("slug")
+	var genre models.Genre
+	if err := database.DB.Where("slug = ?", slug).First(&genre).Error; err != nil {
+		return c.Status(404).JSON(fiber.Map{"status": "error", "message": "Category not found"})
+	}
+
+	page := c.QueryInt("page", 1)
+	limit := c.QueryInt("limit", 20)
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 50 {
+		limit = 20
+	}
+	sortBy := c.Query("sort", "newest")
+	order, ok := categorySorts[sortBy]
+	if !ok {
+		sortBy, order = "newest", categorySorts["newest"]
+	}
+
+	query := models.WithGenre(database.DB.Model(&models.Drama{}), slug).
+		Where("availability IS NULL OR availability <> ?", models.AvailabilityUnavailable)
+	if v := c.Query("dubbed"); v == "true" || v == "false" {
+		query = query.Where("dubbed = ?", v == "true")
+	}
+
+	var total int64
+	query.Count(&total)
+
+	var dramas []models.Drama
+	query.Order(order).Limit(limit).Offset((page - 1) * limit).Find(&dramas)
+
+	upstream := []models.Drama{}
+	if page == 1 {
+		upstream = upstreamExtras(c, slug, sortBy, limit)
+	}
+	models.LoadGenres(database.DB, dramas)
+
+	return c.JSON(fiber.Map{
+		"status": "success",
+		"genre": fiber.Map{
+			"slug": genre.Slug,
+			"name": genre.DisplayName(requestLocale(c)),
+		},
+		"data":     dramas,
+		"upstream": upstream,
+		"total":    total,
+		"page":     page,
+		"limit":    limit,
+		"sort":     sortBy,
+	})
+}
+
+// upstreamExtras returns up to limit tagged upstream items not yet in the
+// catalog, in the requested order. They stay out of the local paging so no
+// catalog item is pushed off a page.
+func upstreamExtras(c *fiber.Ctx, slug, sortBy string, limit int) []models.Drama {
+	upstream := filterDubbed(c, hideUnavailable(AdapterManager.GetGenreFeed(requestLocale(c), slug)))
+	extra := []models.Drama{}
+	if len(upstream) == 0 {
+		return extra
+	}
+
+	ids := make([]string, len(upstream))
+	for i, d := range upstream {
+		ids[i] = d.BookID
+	}
+	var stored []string
+	database.DB.Model(&models.Drama{}).Where("book_id IN ?", ids).Pluck("book_id", &stored)
+	known := make(map[string]bool, len(stored))
+	for _, id := range stored {
+		known[id] = true
+	}
+
+	for _, d := range upstream {
+		if !known[d.BookID] {
+			d.Episodes = nil
+			extra = append(extra, d)
+		}
+	}
+
+	// "newest" keeps the feed order
+	switch sortBy {
+	case "popular":
+		sort.SliceStable(extra, func(i, j int) bool {
+			return extra[i].LikesCount+extra[i].ViewsCount > extra[j].LikesCount+extra[j].ViewsCount
+		})
+	case "episodes":
+		sort.SliceStable(extra, func(i, j int) bool { return extra[i].EpisodeCount > extra[j].EpisodeCount })
+	}
+	if len(extra) > limit {
+		extra = extra[:limit]
+	}
+	return extra
+}
@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"dramabang/database"
+	"dramabang/models"
+	"dramabang/services/credentials"
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// CredentialStore is set in main once the vault has been loaded
+var CredentialStore *credentials.Store
+
+// GetCredentials lists provider tokens (masked)
+func GetCredentials(c *fiber.Ctx) error {
+	if CredentialStore == nil {
+		return c.Status(500).JSON(fiber.Map{"status": "error", "message": "Credential vault unavailable"})
+	}
+	return c.JSON(fiber.Map{"status": "success", "data": CredentialStore.List()})
+}
+
+// AddCredential stores a new encrypted token for a provider
+func AddCredential(c *fiber.Ctx) error {
+	var input struct {
+		Provider string `json:"provider"`
+		Label    string `json:"label"`
+		Token    string `json:"token"`
+	}
+
+	if err := c.BodyParser(&input); err != nil {
+		return c.Status(400).JSON(fiber.Map{"status": "error", "message": "Invalid input"})
+	}
+	if input.Provider == "" || input.Token == "" {
+		return c.Status(400).JSON(fiber.Map{"status": "error", "message": "Provider and token are required"})
+	}
+	if CredentialStore == nil {
+		return c.Status(500).JSON(fiber.Map{"status": "error", "message": "Credential vault unavailable"})
+	}
+
+	cred, err := CredentialStore.Add(input.Provider, input.Label, input.Token)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"status": "error", "message": "Failed to save credential"})
+	}
+
+	models.LogSecurity(database.DB, fmt.Sprintf("[IP: %s] Credential added for %s (%s)", c.IP(), input.Provider, cred.ID))
+	return c.JSON(fiber.Map{"status": "success", "data": cred})
+}
+
+// RevokeCredential removes a token from rotation
+func RevokeCredential(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if CredentialStore == nil {
+		return c.Status(500).JSON(fiber.Map{"status": "error", "message": "Credential vault unavailable"})
+	}
+
+	if err := CredentialStore.Revoke(id); err != nil {
+		return c.Status(404).JSON(fiber.Map{"status": "error", "message": err.Error()})
+	}
+
+	models.LogSecurity(database.DB, fmt.Sprintf("[IP: %s] Credential revoked (%s)", c.IP(), id))
+	return c.JSON(fiber.Map{"status": "success", "message": "Credential revoked"})
+}
@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"dramabang/database"
+	"dramabang/models"
+	"dramabang/services/adapter"
+	"encoding/json"
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// feedRulesKey holds the JSON composition rules for the merged trending/latest feeds
+const feedRulesKey = "feed_rules"
+
+// LoadFeedRules applies stored composition rules to the manager
+func LoadFeedRules() {
+	var setting models.Setting
+	if err := database.DB.Where("key = ?", feedRulesKey).First(&setting).Error; err != nil {
+		return
+	}
+
+	var rules adapter.FeedComposition
+	if err := json.Unmarshal([]byte(setting.Value), &rules); err != nil {
+		fmt.Println("Invalid feed_rules setting:", err)
+		return
+	}
+	AdapterManager.ApplyFeedComposition(rules)
+}
+
+// GetFeedRules returns the active composition rules
+func GetFeedRules(c *fiber.Ctx) error {
+	return c.JSON(fiber.Map{"status": "success", "data": AdapterManager.FeedComposition()})
+}
+
+// UpdateFeedRules validates, saves and applies new composition rules
+func UpdateFeedRules(c *fiber.Ctx) error {
+	var rules adapter.FeedComposition
+	if err := c.BodyParser(&rules); err != nil {
+		return c.Status(400).JSON(fiber.Map{"status": "error", "message": "Invalid input"})
+	}
+	if err := rules.Trending.Validate(); err != nil {
+		return c.Status(400).JSON(fiber.Map{"status": "error", "message": "trending: " + err.Error()})
+	}
+	if err := rules.Latest.Validate(); err != nil {
+		return c.Status(400).JSON(fiber.Map{"status": "error", "message": "latest: " + err.Error()})
+	}
+
+	raw, _ := json.Marshal(rules)
+	if err := database.DB.Save(&models.Setting{Key: feedRulesKey, Value: string(raw)}).Error; err != nil {
+		return c.Status(500).JSON(fiber.Map{"status": "error", "message": "Failed to save feed rules"})
+	}
+	AdapterManager.ApplyFeedComposition(rules)
+
+	models.LogInfo(database.DB, "Feed composition rules updated")
+
+	return c.JSON(fiber.Map{"status": "success", "data": rules})
+}
+
+// PreviewFeed composes page 1 of a feed with unsaved rules so admins can check them
+func PreviewFeed(c *fiber.Ctx) error {
+	var req struct {
+		Feed  string            `json:"feed"`
+		Lang  string            `json:"lang"`
+		Rules adapter.FeedRules `json:"rules"`
+	}
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"status": "error", "message": "Invalid input"})
+	}
+	if req.Feed == "" {
+		req.Feed = "trending"
+	}
+
+	dramas, err := AdapterManager.PreviewFeed(req.Feed, adapter.NormalizeLocale(req.Lang), req.Rules)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"status": "error", "message": err.Error()})
+	}
+
+	return c.JSON(fiber.Map{"status": "success", "feed": req.Feed, "data": dramas})
+}
@@ -0,0 +1,212 @@
+package handlers
+
+import (
+	"dramabang/database"
+	"dramabang/models"
+	"fmt"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// genreInput is the body for creating or editing a genre
+type genreInput struct {
+	Name   *string           `json:"name"`
+	Slug   *string           `json:"slug"`
+	Names  map[string]string `json:"names"`
+	Hidden *bool             `json:"hidden"`
+}
+
+// GetGenres lists the taxonomy with drama counts (?q= filters by name, ?curated=true)
+func GetGenres(c *fiber.Ctx) error {
+	query := database.DB.Model(&models.Genre{})
+	if q := c.Query("q"); q != "" {
+		query = query.Where("LOWER(name) LIKE ?", "%"+strings.ToLower(q)+"%")
+	}
+	if c.Query("curated") == "true" {
+		query = query.Where("curated = ?", true)
+	}
+
+	var genres []models.Genre
+	query.Order("curated desc, name asc").Find(&genres)
+
+	type count struct {
+		GenreID uint
+		Count   int64
+	}
+	var counts []count
+	database.DB.Model(&models.DramaGenre{}).Select("genre_id, count(*) as count").Group("genre_id").Scan(&counts)
+	byID := make(map[uint]int64, len(counts))
+	for _, c := range counts {
+		byID[c.GenreID] = c.Count
+	}
+	for i := range genres {
+		genres[i].Count = byID[genres[i].ID]
+	}
+
+	return c.JSON(fiber.Map{"status": "success", "data": genres})
+}
+
+// CreateGenre adds a curated genre
+func CreateGenre(c *fiber.Ctx) error {
+	var input genreInput
+	if err := c.BodyParser(&input); err != nil || input.Name == nil || strings.TrimSpace(*input.Name) == "" {
+		return c.Status(400).JSON(fiber.Map{"status": "error", "message": "Name is required"})
+	}
+
+	genre := models.Genre{Name: strings.TrimSpace(*input.Name), Names: input.Names, Curated: true}
+	genre.Slug = models.Slugify(genre.Name)
+	if input.Slug != nil {
+		genre.Slug = models.Slugify(*input.Slug)
+	}
+	if input.Hidden != nil {
+		genre.Hidden = *input.Hidden
+	}
+	if genre.Names == nil {
+		genre.Names = map[string]string{}
+	}
+	if genre.Slug == "" {
+		return c.Status(400).JSON(fiber.Map{"status": "error", "message": "Name must contain letters or digits"})
+	}
+
+	var existing int64
+	database.DB.Model(&models.Genre{}).Where("slug = ?", genre.Slug).Count(&existing)
+	if existing > 0 {
+		return c.Status(409).JSON(fiber.Map{"status": "error", "message": "A genre with this slug already exists"})
+	}
+	if err := database.DB.Create(&genre).Error; err != nil {
+		return c.Status(500).JSON(fiber.Map{"status": "error", "message": "Failed to create genre"})
+	}
+
+	models.LogInfo(database.DB, "Genre created: "+genre.Slug)
+	return c.JSON(fiber.Map{"status": "success", "data": genre})
+}
+
+// UpdateGenre edits a genre's names, slug or visibility. Editing marks it curated.
+func UpdateGenre(c *fiber.Ctx) error {
+	var genre models.Genre
+	if err := database.DB.First(&genre, c.Params("id")).Error; err != nil {
+		return c.Status(404).JSON(fiber.Map{"status": "error", "message": "Genre not found"})
+	}
+
+	var input genreInput
+	if err := c.BodyParser(&input); err != nil {
+		return c.Status(400).JSON(fiber.Map{"status": "error", "message": "Invalid input"})
+	}
+
+	renamed := false
+	if input.Name != nil && strings.TrimSpace(*input.Name) != "" && *input.Name != genre.Name {
+		genre.Name = strings.TrimSpace(*input.Name)
+		renamed = true
+	}
+	if input.Slug != nil {
+		slug := models.Slugify(*input.Slug)
+		if slug == "" {
+			return c.Status(400).JSON(fiber.Map{"status": "error", "message": "Invalid slug"})
+		}
+		var existing int64
+		database.DB.Model(&models.Genre{}).Where("slug = ? AND id <> ?", slug, genre.ID).Count(&existing)
+		if existing > 0 {
+			return c.Status(409).JSON(fiber.Map{"status": "error", "message": "A genre with this slug already exists"})
+		}
+		genre.Slug = slug
+	}
+	if input.Names != nil {
+		genre.Names = input.Names
+	}
+	if input.Hidden != nil {
+		genre.Hidden = *input.Hidden
+	}
+	genre.Curated = true
+
+	if err := database.DB.Save(&genre).Error; err != nil {
+		return c.Status(500).JSON(fiber.Map{"status": "error", "message": "Failed to update genre"})
+	}
+
+	if renamed {
+		// Keep the denormalized genre column in step
+		var bookIDs []string
+		database.DB.Model(&models.DramaGenre{}).Where("genre_id = ?", genre.ID).Pluck("book_id", &bookIDs)
+		for _, id := range bookIDs {
+			models.RefreshGenreString(database.DB, id)
+		}
+	}
+
+	return c.JSON(fiber.Map{"status": "success", "data": genre})
+}
+
+// DeleteGenre removes a genre and unlinks it from every drama
+func DeleteGenre(c *fiber.Ctx) error {
+	var genre models.Genre
+	if err := database.DB.First(&genre, c.Params("id")).Error; err != nil {
+		return c.Status(404).JSON(fiber.Map{"status": "error", "message": "Genre not found"})
+	}
+	if err := models.DeleteGenre(database.DB, genre.ID); err != nil {
+		return c.Status(500).JSON(fiber.Map{"status": "error", "message": "Failed to delete genre"})
+	}
+
+	models.LogInfo(database.DB, "Genre deleted: "+genre.Slug)
+	return c.JSON(fiber.Map{"status": "success", "message": "Genre deleted"})
+}
+
+// MergeGenre folds one genre into another (POST {"into": id})
+func MergeGenre(c *fiber.Ctx) error {
+	var from, into models.Genre
+	if err := database.DB.First(&from, c.Params("id")).Error; err != nil {
+		return c.Status(404).JSON(fiber.Map{"status": "error", "message": "Genre not found"})
+	}
+
+	var input struct {
+		Into uint `json:"into"`
+	}
+	if err := c.BodyParser(&input); err != nil || input.Into == 0 || input.Into == from.ID {
+		return c.Status(400).JSON(fiber.Map{"status": "error", "message": "into must be another genre id"})
+	}
+	if err := database.DB.First(&into, input.Into).Error; err != nil {
+		return c.Status(404).JSON(fiber.Map{"status": "error", "message": "Target genre not found"})
+	}
+
+	if err := models.MergeGenre(database.DB, from.ID, into.ID); err != nil {
+		return c.Status(500).JSON(fiber.Map{"status": "error", "message": "Failed to merge genres"})
+	}
+
+	models.LogInfo(database.DB, fmt.Sprintf("Genre %s merged into %s", from.Slug, into.Slug))
+	return c.JSON(fiber.Map{"status": "success", "data": into})
+}
+
+// SetDramaGenres replaces a drama's manually assigned genres and locks them
+// (PUT {"genres": ["Revenge", ...], "locked": true}); "locked": false hands the
+// drama back to the classifier
+func SetDramaGenres(c *fiber.Ctx) error {
+	bookID := c.Params("id")
+	var count int64
+	database.DB.Model(&models.Drama{}).Where("book_id = ?", bookID).Count(&count)
+	if count == 0 {
+		return c.Status(404).JSON(fiber.Map{"status": "error", "message": "Drama not found"})
+	}
+
+	var input struct {
+		Genres []string `json:"genres"`
+		Locked *bool    `json:"locked"`
+	}
+	if err := c.BodyParser(&input); err != nil {
+		return c.Status(400).JSON(fiber.Map{"status": "error", "message": "Invalid input"})
+	}
+
+	if input.Locked != nil && !*input.Locked {
+		if err := models.SetDramaGenres(database.DB, bookID, models.GenreSourceManual, input.Genres); err != nil {
+			return c.Status(500).JSON(fiber.Map{"status": "error", "message": "Failed to save genres"})
+		}
+		database.DB.Model(&models.Drama{}).Where("book_id = ?", bookID).Update("genres_locked", false)
+	} else {
+		if err := lockGenres(bookID, input.Genres); err != nil {
+			return c.Status(500).JSON(fiber.Map{"status": "error", "message": "Failed to save genres"})
+		}
+		// Manual genres answer any pending review
+		var review models.GenreReview
+		if database.DB.Where("book_id = ? AND status = ?", bookID, models.ReviewPending).First(&review).Error == nil {
+			resolveReview(&review, models.ReviewApproved)
+		}
+	}
+	return c.JSON(fiber.Map{"status": "success", "data": models.DramaGenres(database.DB, bookID)})
+}
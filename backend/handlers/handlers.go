@@ -1,13 +1,36 @@
 package handlers
 
 import (
+	"dramabang/database"
 	"dramabang/models"
+	"dramabang/services/adapter"
 
 	"github.com/gofiber/fiber/v2"
 )
 
 // Legacy BaseAPI constant removed as we use AdapterManager now
 
+// requestLocale reads the ?lang= query param, defaulting to the site locale
+func requestLocale(c *fiber.Ctx) string {
+	return adapter.NormalizeLocale(c.Query("lang"))
+}
+
+// filterDubbed applies ?dubbed=true (dubbed only) or ?dubbed=false (subtitled only)
+func filterDubbed(c *fiber.Ctx, dramas []models.Drama) []models.Drama {
+	v := c.Query("dubbed")
+	if v != "true" && v != "false" {
+		return dramas
+	}
+	want := v == "true"
+	out := make([]models.Drama, 0, len(dramas))
+	for _, d := range dramas {
+		if d.Dubbed == want {
+			out = append(out, d)
+		}
+	}
+	return out
+}
+
 // --- Handlers ---
 
 func SeedData(c *fiber.Ctx) error {
@@ -15,10 +38,11 @@ func SeedData(c *fiber.Ctx) error {
 }
 
 func GetTrending(c *fiber.Ctx) error {
-	dramas, err := AdapterManager.GetTrending()
+	dramas, err := AdapterManager.GetTrending(requestLocale(c))
 	if err != nil {
 		return c.Status(502).JSON(fiber.Map{"error": "Failed to fetch trending data", "details": err.Error()})
 	}
+	dramas = filterDubbed(c, hideUnavailable(dramas))
 
 	return c.JSON(fiber.Map{
 		"status": "success",
@@ -30,10 +54,11 @@ func GetTrending(c *fiber.Ctx) error {
 func GetLatest(c *fiber.Ctx) error {
 	page := c.QueryInt("page", 1)
 
-	dramas, err := AdapterManager.GetLatest(page)
+	dramas, err := AdapterManager.GetLatest(requestLocale(c), page)
 	if err != nil {
 		return c.Status(502).JSON(fiber.Map{"error": "Failed to fetch latest data", "details": err.Error()})
 	}
+	dramas = filterDubbed(c, hideUnavailable(dramas))
 
 	return c.JSON(fiber.Map{
 		"status": "success",
@@ -47,7 +72,7 @@ func GetProviderLatest(c *fiber.Ctx) error {
 	provider := c.Params("provider")
 	page := c.QueryInt("page", 1)
 
-	dramas, err := AdapterManager.GetLatestFromProvider(provider, page)
+	dramas, err := AdapterManager.GetLatestFromProvider(requestLocale(c), provider, page)
 	if err != nil {
 		// Return empty list instead of error to prevent frontend crash
 		return c.JSON(fiber.Map{
@@ -57,6 +82,8 @@ func GetProviderLatest(c *fiber.Ctx) error {
 		})
 	}
 
+	dramas = filterDubbed(c, hideUnavailable(dramas))
+
 	return c.JSON(fiber.Map{
 		"status":   "success",
 		"type":     "latest_provider",
@@ -66,59 +93,20 @@ func GetProviderLatest(c *fiber.Ctx) error {
 	})
 }
 
-func GetHero(c *fiber.Ctx) error {
-	// Hero uses top 5 trending dramas
-	dramas, err := AdapterManager.GetTrending()
-	if err != nil {
-		return c.Status(502).JSON(fiber.Map{"error": "Failed to fetch hero data"})
-	}
-
-	limit := 5
-	if len(dramas) < limit {
-		limit = len(dramas)
-	}
-
-	heroDramas := make([]models.Drama, limit)
-	for i := 0; i < limit; i++ {
-		heroDramas[i] = dramas[i]
-		heroDramas[i].IsFeatured = true
-	}
-
-	return c.JSON(fiber.Map{
-		"status": "success",
-		"type":   "hero",
-		"data":   heroDramas,
-	})
-}
-
-func GetSearch(c *fiber.Ctx) error {
-	q := c.Query("q", c.Query("query"))
-	if q == "" {
-		return c.JSON(fiber.Map{"status": "success", "data": []models.Drama{}})
-	}
-
-	dramas, err := AdapterManager.Search(q)
-	if err != nil {
-		return c.Status(502).JSON(fiber.Map{"error": "Search failed", "details": err.Error()})
-	}
-
-	return c.JSON(fiber.Map{
-		"status":        "success",
-		"query":         q,
-		"total_results": len(dramas),
-		"data":          dramas,
-	})
-}
-
 func GetDetail(c *fiber.Ctx) error {
 	bookId := c.Query("bookId")
 	if bookId == "" {
 		return c.Status(400).JSON(fiber.Map{"error": "Missing bookId"})
 	}
 
-	drama, episodes, err := AdapterManager.GetDetail(bookId)
+	drama, episodes, err := AdapterManager.GetDetail(requestLocale(c), bookId)
+	recordLookup(bookId, err)
 	if err != nil {
-		return c.Status(404).JSON(fiber.Map{"error": "Drama not found", "details": err.Error()})
+		return c.Status(404).JSON(fiber.Map{
+			"error":      "Drama not found",
+			"details":    err.Error(),
+			"alternates": alternatesFor(bookId),
+		})
 	}
 
 	return c.JSON(models.DetailResponse{
@@ -141,28 +129,75 @@ func GetStream(c *fiber.Ctx) error {
 		return c.Status(400).JSON(fiber.Map{"error": "Missing bookId"})
 	}
 
-	data, err := AdapterManager.GetStream(bookId, idx)
+	data, err := AdapterManager.GetStream(requestLocale(c), bookId, idx)
 	if err != nil {
 		return c.Status(500).JSON(fiber.Map{"error": "Stream unavailable", "details": err.Error()})
 	}
 
-	return c.JSON(models.StreamResponse{
-		Status: "success",
-		Data:   *data,
-	})
-}
+	resp := models.StreamResponse{
+		Status:   "success",
+		Data:     *data,
+		Duration: data.Chapter.Duration,
+	}
+
+	// Navigation comes from the cached or stored episode list, so playback
+	// never waits on an upstream detail fetch; clients fall back to index +/- 1.
+	episodes, ok := AdapterManager.CachedEpisodes(requestLocale(c), bookId)
+	if !ok {
+		database.DB.Where("book_id = ?", bookId).Order("episode_index").Find(&episodes)
+	}
+	if len(episodes) > 0 {
+		fillStreamNavigation(&resp, episodes)
+	} else {
+		// Only the count is known, not how the provider numbers episodes
+		database.DB.Model(&models.Drama{}).Where("book_id = ?", bookId).Select("episode_count").Scan(&resp.TotalEpisode)
+	}
+
+	resp.Markers = models.FindSkipMarker(database.DB, bookId, data.Chapter.Index)
 
-func GetRandom(c *fiber.Ctx) error {
-	// Fallback to Trending for now
-	return GetTrending(c)
+	return c.JSON(resp)
 }
 
-func GetCategories(c *fiber.Ctx) error {
-	// Not implemented in generic adapter yet
-	return c.JSON(fiber.Map{"status": "success", "data": []string{}})
+// fillStreamNavigation sets prev/next indexes, title and duration from the episode list
+func fillStreamNavigation(resp *models.StreamResponse, episodes []models.Episode) {
+	resp.TotalEpisode = len(episodes)
+	for i, ep := range episodes {
+		if ep.EpisodeIndex != resp.Data.Chapter.Index {
+			continue
+		}
+		resp.EpisodeTitle = ep.EpisodeLabel
+		if resp.Duration == 0 {
+			resp.Duration = ep.Duration
+		}
+		if i > 0 {
+			prev := episodes[i-1].EpisodeIndex
+			resp.PrevIndex = &prev
+		}
+		if i+1 < len(episodes) {
+			next := episodes[i+1].EpisodeIndex
+			resp.NextIndex = &next
+		}
+		return
+	}
 }
 
-func GetSitemapData(c *fiber.Ctx) error {
-	// Unimplemented for external API yet
-	return c.JSON(fiber.Map{"status": "success", "data": []string{}})
+func GetStreamBatch(c *fiber.Ctx) error {
+	bookId := c.Query("bookId")
+	if bookId == "" {
+		return c.Status(400).JSON(fiber.Map{"error": "Missing bookId"})
+	}
+
+	from := c.QueryInt("from", 0)
+	to := c.QueryInt("to", from+4)
+
+	items, err := AdapterManager.GetStreamBatch(requestLocale(c), bookId, from, to)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid batch request", "details": err.Error()})
+	}
+
+	return c.JSON(models.StreamBatchResponse{
+		Status: "success",
+		BookID: bookId,
+		Data:   items,
+	})
 }
@@ -62,7 +62,7 @@ func GetHistory(c *fiber.Ctx) error {
 		if h.Drama.BookID == "" {
 			// Drama missing in local DB (likely from Trending proxy)
 			// Fetch from Universal Adapter Manager
-			fetchedDrama, _, err := AdapterManager.GetDetail(h.BookID)
+			fetchedDrama, _, err := AdapterManager.GetDetail(requestLocale(c), h.BookID)
 			if err == nil && fetchedDrama != nil {
 				// Save to DB
 				database.DB.Save(fetchedDrama)
@@ -70,6 +70,12 @@ func GetHistory(c *fiber.Ctx) error {
 				histories[i].Drama = *fetchedDrama
 			}
 		}
+
+		// Flag delisted dramas and suggest other sources
+		if histories[i].Drama.IsUnavailable() {
+			histories[i].Unavailable = true
+			histories[i].Alternates = models.FindAlternates(database.DB, histories[i].Drama, maxAlternates)
+		}
 	}
 
 	return c.JSON(fiber.Map{
@@ -0,0 +1,368 @@
+package handlers
+
+import (
+	"dramabang/database"
+	"dramabang/models"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+const (
+	// homeLayoutKey holds the JSON home layout served by /api/home
+	homeLayoutKey = "home_layout"
+	// homeHistoryKey holds previous layout versions, newest first
+	homeHistoryKey = "home_layout_history"
+	// homeHistorySize is how many previous versions are kept for rollback
+	homeHistorySize = 20
+)
+
+var (
+	homeLayout   = models.DefaultHomeLayout
+	homeLayoutMu sync.RWMutex
+)
+
+// homeSectionView is a section as served to clients, with its items resolved
+type homeSectionView struct {
+	ID       string      `json:"id"`
+	Type     string      `json:"type"`
+	Title    string      `json:"title"`
+	Source   string      `json:"source"`
+	Provider string      `json:"provider,omitempty"`
+	Genre    string      `json:"genre,omitempty"`
+	Limit    int         `json:"limit"`
+	Endpoint string      `json:"endpoint"` // Paged feed behind the section, for "see all"
+	Items    interface{} `json:"items,omitempty"`
+}
+
+// LoadHomeLayout reads the stored home layout, keeping the default when none is saved
+func LoadHomeLayout() {
+	var setting models.Setting
+	if err := database.DB.Where("key = ?", homeLayoutKey).First(&setting).Error; err != nil {
+		return
+	}
+
+	var layout models.HomeLayout
+	if err := json.Unmarshal([]byte(setting.Value), &layout); err != nil {
+		fmt.Println("Invalid home_layout setting:", err)
+		return
+	}
+	if err := layout.Validate(); err != nil {
+		fmt.Println("Invalid home_layout setting:", err)
+		return
+	}
+	setHomeLayout(layout)
+}
+
+func currentHomeLayout() models.HomeLayout {
+	homeLayoutMu.RLock()
+	defer homeLayoutMu.RUnlock()
+	return homeLayout
+}
+
+func setHomeLayout(layout models.HomeLayout) {
+	homeLayoutMu.Lock()
+	homeLayout = layout
+	homeLayoutMu.Unlock()
+}
+
+// GetHome returns the homepage sections in order with their first items.
+// ?data=false returns the layout only, leaving clients to call each endpoint.
+// Continue watching is left out for guests.
+func GetHome(c *fiber.Ctx) error {
+	layout := currentHomeLayout()
+	locale := requestLocale(c)
+	userID, signedIn := optionalUserID(c)
+	withData := c.Query("data") != "false"
+
+	views := make([]homeSectionView, 0, len(layout.Sections))
+	for _, s := range layout.Sections {
+		if s.Hidden || (s.Source == models.HomeSourceContinueWatching && !signedIn) {
+			continue
+		}
+		views = append(views, homeSectionView{
+			ID:       s.ID,
+			Type:     s.Type,
+			Title:    s.DisplayTitle(locale),
+			Source:   s.Source,
+			Provider: s.Provider,
+			Genre:    s.Genre,
+			Limit:    s.Limit,
+			Endpoint: homeEndpoint(s, userID),
+		})
+	}
+
+	if withData {
+		// Sections hit different upstreams, so resolve them side by side
+		var wg sync.WaitGroup
+		for i := range views {
+			wg.Add(1)
+			go func(v *homeSectionView) {
+				defer wg.Done()
+				v.Items = resolveHomeSection(*v, locale, userID, signedIn)
+			}(&views[i])
+		}
+		wg.Wait()
+
+		// Drop rails that came back empty rather than render blank rows
+		kept := views[:0]
+		for _, v := range views {
+			if v.Items != nil {
+				kept = append(kept, v)
+			}
+		}
+		views = kept
+	}
+
+	return c.JSON(fiber.Map{
+		"status":     "success",
+		"version":    layout.Version,
+		"updated_at": layout.UpdatedAt,
+		"data":       views,
+	})
+}
+
+// homeEndpoint is the existing API a section's items come from
+func homeEndpoint(s models.HomeSection, userID uint) string {
+	switch s.Source {
+	case models.HomeSourceFeatured:
+		return "/api/hero"
+	case models.HomeSourceTrending:
+		return "/api/trending"
+	case models.HomeSourceLatest:
+		return "/api/latest"
+	case models.HomeSourceProviderLatest:
+		return "/api/provider/" + url.PathEscape(s.Provider) + "/latest"
+	case models.HomeSourceGenre:
+		return "/api/categories/" + url.PathEscape(s.Genre)
+	case models.HomeSourceContinueWatching:
+		return fmt.Sprintf("/api/history?userId=%d", userID)
+	}
+	return "/api/random"
+}
+
+// resolveHomeSection fetches a section's items, returning nil when there are none
+func resolveHomeSection(v homeSectionView, locale string, userID uint, signedIn bool) interface{} {
+	switch v.Source {
+	case models.HomeSourceFeatured:
+		items, _, err := heroItems(locale)
+		if err != nil || len(items) == 0 {
+			return nil
+		}
+		return items[:min(len(items), v.Limit)]
+
+	case models.HomeSourceContinueWatching:
+		var histories []models.UserHistory
+		database.DB.Preload("Drama").Where("user_id = ?", userID).
+			Order("updated_at desc").Limit(v.Limit).Find(&histories)
+		if len(histories) == 0 {
+			return nil
+		}
+		return histories
+	}
+
+	var dramas []models.Drama
+	var err error
+	switch v.Source {
+	case models.HomeSourceTrending:
+		dramas, err = AdapterManager.GetTrending(locale)
+	case models.HomeSourceLatest:
+		dramas, err = AdapterManager.GetLatest(locale, 1)
+	case models.HomeSourceProviderLatest:
+		dramas, err = AdapterManager.GetLatestFromProvider(locale, v.Provider, 1)
+	case models.HomeSourceGenre:
+		dramas = popularDramas(models.WithGenre(availableDramas(), v.Genre), v.Limit)
+	case models.HomeSourceRecommendations:
+		dramas = recommendedDramas(userID, signedIn, v.Limit)
+	}
+	if err != nil {
+		return nil
+	}
+
+	dramas = hideUnavailable(dramas)
+	if len(dramas) == 0 {
+		return nil
+	}
+	return dramas[:min(len(dramas), v.Limit)]
+}
+
+func availableDramas() *gorm.DB {
+	return database.DB.Model(&models.Drama{}).
+		Where("availability IS NULL OR availability <> ?", models.AvailabilityUnavailable)
+}
+
+func popularDramas(query *gorm.DB, limit int) []models.Drama {
+	var dramas []models.Drama
+	query.Order("likes_count + views_count desc, book_id").Limit(limit).Find(&dramas)
+	return dramas
+}
+
+// recommendedDramas picks unwatched dramas sharing genres with the user's
+// history; guests and users without history get the most popular dramas
+func recommendedDramas(userID uint, signedIn bool, limit int) []models.Drama {
+	if !signedIn {
+		return popularDramas(availableDramas(), limit)
+	}
+
+	watched := database.DB.Model(&models.UserHistory{}).Select("book_id").Where("user_id = ?", userID)
+	var genreIDs []uint
+	database.DB.Model(&models.DramaGenre{}).Distinct("genre_id").
+		Where("book_id IN (?)", watched).Pluck("genre_id", &genreIDs)
+
+	query := availableDramas().Where("book_id NOT IN (?)", watched)
+	if len(genreIDs) > 0 {
+		inGenres := database.DB.Model(&models.DramaGenre{}).Select("book_id").Where("genre_id IN ?", genreIDs)
+		if dramas := popularDramas(query.Session(&gorm.Session{}).Where("book_id IN (?)", inGenres), limit); len(dramas) > 0 {
+			return dramas
+		}
+	}
+	return popularDramas(query, limit)
+}
+
+// GetHomeLayout returns the stored layout for the admin editor
+func GetHomeLayout(c *fiber.Ctx) error {
+	return c.JSON(fiber.Map{"status": "success", "data": currentHomeLayout()})
+}
+
+// UpdateHomeLayout validates and saves a new layout version. When the body
+// carries "version", it must match the current one so concurrent edits don't
+// overwrite each other.
+func UpdateHomeLayout(c *fiber.Ctx) error {
+	var layout models.HomeLayout
+	if err := c.BodyParser(&layout); err != nil {
+		return c.Status(400).JSON(fiber.Map{"status": "error", "message": "Invalid input"})
+	}
+	if msg := validateHomeLayout(&layout); msg != "" {
+		return c.Status(400).JSON(fiber.Map{"status": "error", "message": msg})
+	}
+
+	saved, err := saveHomeLayout(layout, layout.Version)
+	if errors.Is(err, errHomeLayoutConflict) {
+		return c.Status(409).JSON(fiber.Map{
+			"status":  "error",
+			"message": fmt.Sprintf("Layout changed since version %d; reload and try again", layout.Version),
+			"version": saved.Version,
+		})
+	}
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"status": "error", "message": "Failed to save home layout"})
+	}
+	models.LogInfo(database.DB, fmt.Sprintf("Home layout updated to version %d", saved.Version))
+	return c.JSON(fiber.Map{"status": "success", "data": saved})
+}
+
+// GetHomeLayoutHistory lists previous layout versions, newest first
+func GetHomeLayoutHistory(c *fiber.Ctx) error {
+	return c.JSON(fiber.Map{"status": "success", "data": homeLayoutHistory()})
+}
+
+// RollbackHomeLayout republishes a previous version's sections as a new version ({"version": 3})
+func RollbackHomeLayout(c *fiber.Ctx) error {
+	var input struct {
+		Version int `json:"version"`
+	}
+	if err := c.BodyParser(&input); err != nil || input.Version < 0 {
+		return c.Status(400).JSON(fiber.Map{"status": "error", "message": "version is required"})
+	}
+
+	var target *models.HomeLayout
+	if input.Version == 0 {
+		def := models.DefaultHomeLayout
+		target = &def
+	}
+	for _, l := range homeLayoutHistory() {
+		if l.Version == input.Version {
+			target = &l
+			break
+		}
+	}
+	if target == nil {
+		return c.Status(404).JSON(fiber.Map{"status": "error", "message": "Version not found"})
+	}
+
+	layout := models.HomeLayout{
+		// Copied: validation trims in place and target may be the shared default
+		Sections: append([]models.HomeSection(nil), target.Sections...),
+		Note:     fmt.Sprintf("Rollback to version %d", input.Version),
+	}
+	if msg := validateHomeLayout(&layout); msg != "" {
+		return c.Status(400).JSON(fiber.Map{"status": "error", "message": msg})
+	}
+	saved, err := saveHomeLayout(layout, 0)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"status": "error", "message": "Failed to save home layout"})
+	}
+	models.LogInfo(database.DB, fmt.Sprintf("Home layout rolled back to version %d as version %d", input.Version, saved.Version))
+	return c.JSON(fiber.Map{"status": "success", "data": saved})
+}
+
+// validateHomeLayout checks the layout and its providers, returning an error message
+func validateHomeLayout(layout *models.HomeLayout) string {
+	for i := range layout.Sections {
+		s := &layout.Sections[i]
+		s.ID = strings.TrimSpace(s.ID)
+		s.Title = strings.TrimSpace(s.Title)
+		s.Genre = models.Slugify(s.Genre)
+	}
+	if err := layout.Validate(); err != nil {
+		return err.Error()
+	}
+	for _, s := range layout.Sections {
+		if s.Source == models.HomeSourceProviderLatest && !AdapterManager.HasProvider(s.Provider) {
+			return fmt.Sprintf("section %s: unknown provider %q", s.ID, s.Provider)
+		}
+	}
+	return ""
+}
+
+// errHomeLayoutConflict is returned by saveHomeLayout when the layout was edited since baseVersion
+var errHomeLayoutConflict = errors.New("home layout changed")
+
+// saveHomeLayout stores layout as the next version and pushes the current one onto the history.
+// A non-zero baseVersion must match the current version; on conflict the current layout is returned.
+func saveHomeLayout(layout models.HomeLayout, baseVersion int) (models.HomeLayout, error) {
+	homeLayoutMu.Lock()
+	defer homeLayoutMu.Unlock()
+
+	previous := homeLayout
+	if baseVersion != 0 && baseVersion != previous.Version {
+		return previous, errHomeLayoutConflict
+	}
+	layout.Version = previous.Version + 1
+	layout.UpdatedAt = time.Now()
+
+	history := append([]models.HomeLayout{previous}, homeLayoutHistory()...)
+	if len(history) > homeHistorySize {
+		history = history[:homeHistorySize]
+	}
+
+	raw, _ := json.Marshal(layout)
+	rawHistory, _ := json.Marshal(history)
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(&models.Setting{Key: homeLayoutKey, Value: string(raw)}).Error; err != nil {
+			return err
+		}
+		return tx.Save(&models.Setting{Key: homeHistoryKey, Value: string(rawHistory)}).Error
+	})
+	if err != nil {
+		return models.HomeLayout{}, err
+	}
+	homeLayout = layout
+	return layout, nil
+}
+
+func homeLayoutHistory() []models.HomeLayout {
+	history := []models.HomeLayout{}
+	var setting models.Setting
+	if err := database.DB.Where("key = ?", homeHistoryKey).First(&setting).Error; err == nil {
+		json.Unmarshal([]byte(setting.Value), &history)
+	}
+	return history
+}
@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"dramabang/services/imageproxy"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+var (
+	imageProxy     *imageproxy.Proxy
+	imageProxyErr  error
+	imageProxyOnce sync.Once
+)
+
+// getImageProxy lazily builds the proxy so env from .env is already loaded
+func getImageProxy() (*imageproxy.Proxy, error) {
+	imageProxyOnce.Do(func() {
+		imageProxy, imageProxyErr = imageproxy.New()
+	})
+	return imageProxy, imageProxyErr
+}
+
+// GetImage serves a resized, re-encoded copy of an allowed upstream image.
+// Query: url (required), w (width), fmt ("jpg" | "webp", default picks from Accept)
+func GetImage(c *fiber.Ctx) error {
+	src := c.Query("url")
+	if src == "" {
+		return c.Status(400).JSON(fiber.Map{"error": "Missing url"})
+	}
+
+	proxy, err := getImageProxy()
+	if err != nil {
+		fmt.Println("Image proxy init error:", err)
+		return c.Status(500).JSON(fiber.Map{"error": "Image proxy unavailable"})
+	}
+
+	if !proxy.IsAllowed(src) {
+		return c.Status(403).JSON(fiber.Map{"error": "Image host not allowed"})
+	}
+
+	format := c.Query("fmt")
+	if format == "" {
+		// Negotiate from Accept header
+		c.Vary(fiber.HeaderAccept)
+		if strings.Contains(c.Get(fiber.HeaderAccept), "image/webp") {
+			format = imageproxy.FormatWebP
+		}
+	}
+
+	img, err := proxy.Get(src, c.QueryInt("w", 0), format)
+	if err != nil {
+		return c.Status(502).JSON(fiber.Map{"error": "Failed to fetch image", "details": err.Error()})
+	}
+
+	c.Set(fiber.HeaderETag, img.ETag)
+	c.Set(fiber.HeaderCacheControl, "public, max-age=604800, immutable")
+	if c.Get(fiber.HeaderIfNoneMatch) == img.ETag {
+		return c.SendStatus(fiber.StatusNotModified)
+	}
+
+	c.Set(fiber.HeaderContentType, img.ContentType)
+	return c.Send(img.Data)
+}
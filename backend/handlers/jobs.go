@@ -0,0 +1,156 @@
+package handlers
+
+import (
+	"dramabang/database"
+	"dramabang/models"
+	"dramabang/services/jobs"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// jobSchedulesKey holds cron expressions per job type, e.g. {"ingest": "0 */6 * * *", "cleanup": "@daily"}
+const jobSchedulesKey = "job_schedules"
+
+// defaultSchedules apply to job types missing from the stored job_schedules
+var defaultSchedules = map[string]string{"sitemap": "@daily"}
+
+// JobScheduler runs ingest, dedup, classify, cleanup, backfill and sitemap inside the server
+var JobScheduler *jobs.Scheduler
+
+// InitJobs registers the background tasks, applies stored schedules and starts the scheduler
+func InitJobs() {
+	JobScheduler = jobs.New(database.DB)
+	JobScheduler.Register("ingest", jobs.Ingest(AdapterManager))
+	JobScheduler.Register("dedup", jobs.Dedup)
+	JobScheduler.Register("classify", jobs.Classify)
+	JobScheduler.Register("cleanup", jobs.Cleanup)
+	JobScheduler.Register("backfill", jobs.Backfill(AdapterManager))
+	JobScheduler.Register("sitemap", jobs.Sitemap)
+
+	exprs := map[string]string{}
+	var setting models.Setting
+	if err := database.DB.Where("key = ?", jobSchedulesKey).First(&setting).Error; err == nil {
+		if err := json.Unmarshal([]byte(setting.Value), &exprs); err != nil {
+			fmt.Println("Invalid job_schedules setting:", err)
+		}
+	}
+	if err := JobScheduler.SetSchedules(withDefaultSchedules(exprs)); err != nil {
+		models.LogError(database.DB, "Job schedules: "+err.Error())
+	}
+
+	JobScheduler.Start()
+}
+
+// withDefaultSchedules returns a copy of exprs with defaultSchedules filled in;
+// an explicit empty expr still disables a type
+func withDefaultSchedules(exprs map[string]string) map[string]string {
+	out := make(map[string]string, len(exprs)+len(defaultSchedules))
+	for jobType, expr := range defaultSchedules {
+		out[jobType] = expr
+	}
+	for jobType, expr := range exprs {
+		out[jobType] = expr
+	}
+	return out
+}
+
+// triggerJob starts a job and maps scheduler errors to HTTP responses
+func triggerJob(c *fiber.Ctx, jobType string) error {
+	job, err := JobScheduler.Trigger(jobType, "manual")
+	if errors.Is(err, jobs.ErrUnknownType) {
+		return c.Status(404).JSON(fiber.Map{"status": "error", "message": "Unknown job type"})
+	}
+	if errors.Is(err, jobs.ErrAlreadyRunning) {
+		return c.Status(409).JSON(fiber.Map{"status": "error", "message": "Job already running"})
+	}
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"status": "error", "message": "Failed to start job"})
+	}
+
+	models.LogInfo(database.DB, fmt.Sprintf("Job #%d (%s) triggered", job.ID, jobType))
+	return c.JSON(fiber.Map{"status": "success", "message": jobType + " job started", "data": job})
+}
+
+// GetJobs lists recent jobs without their logs (?type=, ?status=, ?limit=)
+func GetJobs(c *fiber.Ctx) error {
+	limit := c.QueryInt("limit", 50)
+	if limit < 1 || limit > 200 {
+		limit = 50
+	}
+
+	query := database.DB.Model(&models.Job{}).Omit("log").Order("id desc").Limit(limit)
+	if t := c.Query("type"); t != "" {
+		query = query.Where("type = ?", t)
+	}
+	if s := c.Query("status"); s != "" {
+		query = query.Where("status = ?", s)
+	}
+
+	var list []models.Job
+	if err := query.Find(&list).Error; err != nil {
+		return c.Status(500).JSON(fiber.Map{"status": "error", "message": "Failed to fetch jobs"})
+	}
+
+	return c.JSON(fiber.Map{"status": "success", "data": list, "schedules": JobScheduler.Schedules()})
+}
+
+// GetJob returns one job including its full log
+func GetJob(c *fiber.Ctx) error {
+	var job models.Job
+	if err := database.DB.First(&job, "id = ?", c.Params("id")).Error; err != nil {
+		return c.Status(404).JSON(fiber.Map{"status": "error", "message": "Job not found"})
+	}
+	return c.JSON(fiber.Map{"status": "success", "data": job})
+}
+
+// RunJob triggers a job by type ({"type": "ingest"})
+func RunJob(c *fiber.Ctx) error {
+	var req struct {
+		Type string `json:"type"`
+	}
+	if err := c.BodyParser(&req); err != nil || req.Type == "" {
+		return c.Status(400).JSON(fiber.Map{"status": "error", "message": "Invalid input"})
+	}
+	return triggerJob(c, req.Type)
+}
+
+// CancelJob asks a running job to stop
+func CancelJob(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"status": "error", "message": "Invalid job id"})
+	}
+	if err := JobScheduler.Cancel(uint(id)); err != nil {
+		return c.Status(409).JSON(fiber.Map{"status": "error", "message": "Job is not running"})
+	}
+
+	models.LogInfo(database.DB, fmt.Sprintf("Job #%d cancellation requested", id))
+	return c.JSON(fiber.Map{"status": "success", "message": "Cancellation requested"})
+}
+
+// GetJobSchedules returns each job type with its cron schedule and next run
+func GetJobSchedules(c *fiber.Ctx) error {
+	return c.JSON(fiber.Map{"status": "success", "data": JobScheduler.Schedules()})
+}
+
+// UpdateJobSchedules replaces the cron schedules ({type: expr}, empty disables)
+func UpdateJobSchedules(c *fiber.Ctx) error {
+	var exprs map[string]string
+	if err := c.BodyParser(&exprs); err != nil {
+		return c.Status(400).JSON(fiber.Map{"status": "error", "message": "Invalid input"})
+	}
+	if err := JobScheduler.SetSchedules(withDefaultSchedules(exprs)); err != nil {
+		return c.Status(400).JSON(fiber.Map{"status": "error", "message": err.Error()})
+	}
+
+	raw, _ := json.Marshal(exprs)
+	if err := database.DB.Save(&models.Setting{Key: jobSchedulesKey, Value: string(raw)}).Error; err != nil {
+		return c.Status(500).JSON(fiber.Map{"status": "error", "message": "Failed to save schedules"})
+	}
+	models.LogInfo(database.DB, "Job schedules updated")
+
+	return c.JSON(fiber.Map{"status": "success", "data": JobScheduler.Schedules()})
+}
@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"dramabang/database"
+	"dramabang/models"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetSkipMarkers lists all intro/outro markers for a drama
+func GetSkipMarkers(c *fiber.Ctx) error {
+	bookId := c.Params("bookId")
+
+	var markers []models.SkipMarker
+	if err := database.DB.Where("book_id = ?", bookId).Order("episode_index asc").Find(&markers).Error; err != nil {
+		return c.Status(500).JSON(fiber.Map{"status": "error", "message": "Database error"})
+	}
+
+	return c.JSON(fiber.Map{"status": "success", "data": markers})
+}
+
+// SaveSkipMarker creates or updates the marker for a drama (or one of its episodes)
+func SaveSkipMarker(c *fiber.Ctx) error {
+	var input models.SkipMarker
+	if err := c.BodyParser(&input); err != nil {
+		return c.Status(400).JSON(fiber.Map{"status": "error", "message": "Invalid input"})
+	}
+
+	if input.BookID == "" {
+		return c.Status(400).JSON(fiber.Map{"status": "error", "message": "Missing bookId"})
+	}
+	if input.IntroEnd < input.IntroStart || input.OutroEnd < input.OutroStart {
+		return c.Status(400).JSON(fiber.Map{"status": "error", "message": "Marker end must be after start"})
+	}
+
+	var marker models.SkipMarker
+	query := database.DB.Where("book_id = ?", input.BookID)
+	if input.EpisodeIndex == nil {
+		query = query.Where("episode_index IS NULL")
+	} else {
+		query = query.Where("episode_index = ?", *input.EpisodeIndex)
+	}
+
+	if err := query.First(&marker).Error; err != nil {
+		// New marker
+		marker = input
+		marker.ID = 0
+		if err := database.DB.Create(&marker).Error; err != nil {
+			return c.Status(500).JSON(fiber.Map{"status": "error", "message": "Failed to create marker"})
+		}
+	} else {
+		// Update existing
+		marker.IntroStart = input.IntroStart
+		marker.IntroEnd = input.IntroEnd
+		marker.OutroStart = input.OutroStart
+		marker.OutroEnd = input.OutroEnd
+		if err := database.DB.Save(&marker).Error; err != nil {
+			return c.Status(500).JSON(fiber.Map{"status": "error", "message": "Failed to update marker"})
+		}
+	}
+
+	return c.JSON(fiber.Map{"status": "success", "data": marker})
+}
+
+// DeleteSkipMarker removes a marker by ID
+func DeleteSkipMarker(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if err := database.DB.Delete(&models.SkipMarker{}, "id = ?", id).Error; err != nil {
+		return c.Status(500).JSON(fiber.Map{"status": "error", "message": "Failed to delete"})
+	}
+	return c.JSON(fiber.Map{"status": "success", "message": "Marker deleted"})
+}
@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"dramabang/database"
+	"dramabang/models"
+	"dramabang/services/adapter"
+	"encoding/json"
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// providerSettingsKey holds the JSON switches, e.g. {"freeshort": {"enabled": false, "priority": 9}}
+const providerSettingsKey = "provider_settings"
+
+func loadProviderSettings() map[string]adapter.ProviderSettings {
+	cfg := map[string]adapter.ProviderSettings{}
+
+	var setting models.Setting
+	if err := database.DB.Where("key = ?", providerSettingsKey).First(&setting).Error; err != nil {
+		return cfg
+	}
+	if err := json.Unmarshal([]byte(setting.Value), &cfg); err != nil {
+		fmt.Println("Invalid provider_settings setting:", err)
+		return map[string]adapter.ProviderSettings{}
+	}
+	return cfg
+}
+
+// LoadProviderSettings applies stored enabled/priority switches to the manager
+func LoadProviderSettings() {
+	AdapterManager.ApplyProviderSettings(loadProviderSettings())
+}
+
+// GetProviders lists every provider with capabilities, switches, cache stats and errors
+func GetProviders(c *fiber.Ctx) error {
+	return c.JSON(fiber.Map{"status": "success", "data": AdapterManager.Providers()})
+}
+
+// UpdateProvider toggles a provider and/or changes its merge priority
+func UpdateProvider(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if !AdapterManager.HasProvider(id) {
+		return c.Status(404).JSON(fiber.Map{"status": "error", "message": "Provider not found"})
+	}
+
+	var req struct {
+		Enabled  *bool `json:"enabled"`
+		Priority *int  `json:"priority"`
+	}
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"status": "error", "message": "Invalid input"})
+	}
+
+	settings := AdapterManager.ProviderSettings()
+	s := settings[id]
+	if req.Enabled != nil {
+		s.Enabled = *req.Enabled
+	}
+	if req.Priority != nil {
+		s.Priority = *req.Priority
+	}
+	settings[id] = s
+
+	raw, _ := json.Marshal(settings)
+	if err := database.DB.Save(&models.Setting{Key: providerSettingsKey, Value: string(raw)}).Error; err != nil {
+		return c.Status(500).JSON(fiber.Map{"status": "error", "message": "Failed to save provider settings"})
+	}
+	AdapterManager.ApplyProviderSettings(settings)
+
+	models.LogInfo(database.DB, fmt.Sprintf("Provider %s updated: enabled=%t priority=%d", id, s.Enabled, s.Priority))
+
+	return c.JSON(fiber.Map{"status": "success", "data": s})
+}
+
+// TestProvider runs a live call (trending, latest, search, detail, stream) and
+// returns the raw upstream responses next to the normalized result
+func TestProvider(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if !AdapterManager.HasProvider(id) {
+		return c.Status(404).JSON(fiber.Map{"status": "error", "message": "Provider not found"})
+	}
+
+	var req struct {
+		Op      string `json:"op"`
+		Query   string `json:"query"`   // search
+		ID      string `json:"id"`      // detail / stream (raw ID, without prefix)
+		Episode string `json:"episode"` // stream
+		Lang    string `json:"lang"`
+	}
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"status": "error", "message": "Invalid input"})
+	}
+	if req.Op == "" {
+		req.Op = "trending"
+	}
+	if req.Episode == "" {
+		req.Episode = "1"
+	}
+
+	arg := req.ID
+	if req.Op == "search" {
+		arg = req.Query
+	}
+
+	result, err := AdapterManager.TestProvider(id, req.Op, adapter.NormalizeLocale(req.Lang), arg, req.Episode)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"status": "error", "message": err.Error()})
+	}
+
+	return c.JSON(fiber.Map{"status": "success", "data": result})
+}
@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"dramabang/database"
+	"dramabang/models"
+	"dramabang/utils"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// optionalUserID returns the signed-in user from a Bearer token, if any
+func optionalUserID(c *fiber.Ctx) (uint, bool) {
+	auth := c.Get("Authorization")
+	if auth == "" {
+		return 0, false
+	}
+	claims, err := utils.ValidateToken(strings.TrimPrefix(auth, "Bearer "))
+	if err != nil {
+		return 0, false
+	}
+	sub, ok := claims["sub"].(float64) // JWT numbers are float64 by default
+	return uint(sub), ok
+}
+
+// GetRandom samples available dramas from the local catalog. The same seed
+// returns the same order, so clients can page through one shuffle.
+// Filters: ?genre= (slug or name), ?provider=, ?min_episodes=, ?max_episodes=,
+// ?dubbed=. Signed-in users don't get dramas from their history.
+func GetRandom(c *fiber.Ctx) error {
+	page := c.QueryInt("page", 1)
+	limit := c.QueryInt("limit", 20)
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 50 {
+		limit = 20
+	}
+	seed := int64(c.QueryInt("seed", 0))
+	if seed == 0 {
+		seed = time.Now().UnixNano() & 0x7fffffff
+	}
+
+	query := database.DB.Model(&models.Drama{}).
+		Where("availability IS NULL OR availability <> ?", models.AvailabilityUnavailable)
+	filtered := false
+	if g := c.Query("genre"); g != "" {
+		query = models.WithGenre(query, models.Slugify(g))
+		filtered = true
+	}
+	if p := c.Query("provider"); p != "" {
+		if p == "dramabox" {
+			// Legacy dramabox IDs carry no prefix
+			query = query.Where("book_id NOT LIKE ?", "%:%")
+		} else {
+			query = query.Where("book_id LIKE ?", p+":%")
+		}
+		filtered = true
+	}
+	if v := c.QueryInt("min_episodes"); v > 0 {
+		query = query.Where("episode_count >= ?", v)
+		filtered = true
+	}
+	if v := c.QueryInt("max_episodes"); v > 0 {
+		query = query.Where("episode_count <= ?", v)
+		filtered = true
+	}
+	if v := c.Query("dubbed"); v == "true" || v == "false" {
+		query = query.Where("dubbed = ?", v == "true")
+		filtered = true
+	}
+	userID, signedIn := optionalUserID(c)
+	if signedIn {
+		watched := database.DB.Model(&models.UserHistory{}).Select("book_id").Where("user_id = ?", userID)
+		query = query.Where("book_id NOT IN (?)", watched)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return c.Status(500).JSON(fiber.Map{"status": "error", "message": "Database error"})
+	}
+	if total == 0 && !filtered && !signedIn {
+		// Empty catalog (fresh install): show something rather than nothing.
+		// A signed-in user who has watched everything gets an empty list instead.
+		return GetTrending(c)
+	}
+
+	dramas := []models.Drama{}
+	query.Order(models.ShuffleOrder(database.DB, seed)).Limit(limit).Offset((page - 1) * limit).Find(&dramas)
+
+	return c.JSON(fiber.Map{
+		"status": "success",
+		"data":   dramas,
+		"seed":   seed,
+		"page":   page,
+		"limit":  limit,
+		"total":  total,
+	})
+}
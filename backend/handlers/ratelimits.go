@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"dramabang/database"
+	"dramabang/models"
+	"dramabang/services/ratelimit"
+	"encoding/json"
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// rateLimitsKey holds the JSON rules, e.g. {"host:dramabos.asia": {"rate": 5, "burst": 10, "daily_quota": 20000}}
+const rateLimitsKey = "rate_limits"
+
+// loadRateLimitRules reads the stored rules (empty map if unset or invalid)
+func loadRateLimitRules() map[string]ratelimit.Rule {
+	rules := map[string]ratelimit.Rule{}
+
+	var setting models.Setting
+	if err := database.DB.Where("key = ?", rateLimitsKey).First(&setting).Error; err != nil {
+		return rules
+	}
+	if err := json.Unmarshal([]byte(setting.Value), &rules); err != nil {
+		fmt.Println("Invalid rate_limits setting:", err)
+		return map[string]ratelimit.Rule{}
+	}
+	return rules
+}
+
+// LoadRateLimits applies stored outbound rate limits to the adapter manager
+func LoadRateLimits() {
+	AdapterManager.ConfigureLimits(loadRateLimitRules())
+}
+
+// GetRateLimits returns configured rules and today's usage per bucket
+func GetRateLimits(c *fiber.Ctx) error {
+	return c.JSON(fiber.Map{
+		"status": "success",
+		"rules":  loadRateLimitRules(),
+		"data":   AdapterManager.RateLimitUsage(),
+	})
+}
+
+// UpdateRateLimits replaces the stored rules and applies them immediately
+func UpdateRateLimits(c *fiber.Ctx) error {
+	var rules map[string]ratelimit.Rule
+	if err := c.BodyParser(&rules); err != nil {
+		return c.Status(400).JSON(fiber.Map{"status": "error", "message": "Invalid input"})
+	}
+
+	for key, rule := range rules {
+		if rule.Rate < 0 || rule.Burst < 0 || rule.DailyQuota < 0 {
+			return c.Status(400).JSON(fiber.Map{"status": "error", "message": "Invalid rule for " + key})
+		}
+	}
+
+	raw, _ := json.Marshal(rules)
+	if err := database.DB.Save(&models.Setting{Key: rateLimitsKey, Value: string(raw)}).Error; err != nil {
+		return c.Status(500).JSON(fiber.Map{"status": "error", "message": "Failed to save rate limits"})
+	}
+
+	AdapterManager.ConfigureLimits(rules)
+	models.LogInfo(database.DB, "Outbound rate limits updated")
+
+	return c.JSON(fiber.Map{"status": "success", "data": AdapterManager.RateLimitUsage()})
+}
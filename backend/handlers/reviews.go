@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"dramabang/database"
+	"dramabang/models"
+	"dramabang/services/classifier"
+	"fmt"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetGenreReviews lists the classifier review queue (?status=pending|approved|rejected, ?page=, ?limit=)
+func GetGenreReviews(c *fiber.Ctx) error {
+	status := c.Query("status", models.ReviewPending)
+	page := c.QueryInt("page", 1)
+	limit := c.QueryInt("limit", 20)
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	query := database.DB.Model(&models.GenreReview{}).Where("status = ?", status)
+	var total int64
+	query.Count(&total)
+
+	var reviews []models.GenreReview
+	query.Order("created_at asc").Limit(limit).Offset((page - 1) * limit).Find(&reviews)
+
+	return c.JSON(fiber.Map{
+		"status": "success",
+		"data":   reviews,
+		"total":  total,
+		"page":   page,
+		"limit":  limit,
+	})
+}
+
+// ApproveGenreReview assigns genres to a queued drama and locks them.
+// Body {"genres": [...]} overrides the suggestions; omit it to accept them.
+func ApproveGenreReview(c *fiber.Ctx) error {
+	var review models.GenreReview
+	if err := database.DB.First(&review, c.Params("id")).Error; err != nil {
+		return c.Status(404).JSON(fiber.Map{"status": "error", "message": "Review not found"})
+	}
+
+	var input struct {
+		Genres []string `json:"genres"`
+	}
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&input); err != nil {
+			return c.Status(400).JSON(fiber.Map{"status": "error", "message": "Invalid input"})
+		}
+	}
+	genres := input.Genres
+	if genres == nil {
+		for _, s := range review.Suggestions {
+			genres = append(genres, s.Name)
+		}
+	}
+	if len(genres) == 0 {
+		return c.Status(400).JSON(fiber.Map{"status": "error", "message": "No genres to apply"})
+	}
+
+	if err := lockGenres(review.BookID, genres); err != nil {
+		return c.Status(500).JSON(fiber.Map{"status": "error", "message": "Failed to save genres"})
+	}
+	resolveReview(&review, models.ReviewApproved)
+
+	return c.JSON(fiber.Map{"status": "success", "data": review})
+}
+
+// RejectGenreReview dismisses a queued drama; the classifier won't queue it again
+func RejectGenreReview(c *fiber.Ctx) error {
+	var review models.GenreReview
+	if err := database.DB.First(&review, c.Params("id")).Error; err != nil {
+		return c.Status(404).JSON(fiber.Map{"status": "error", "message": "Review not found"})
+	}
+	resolveReview(&review, models.ReviewRejected)
+	return c.JSON(fiber.Map{"status": "success", "data": review})
+}
+
+// ExplainClassification shows what the classifier would assign to a drama and why
+func ExplainClassification(c *fiber.Ctx) error {
+	var drama models.Drama
+	if err := database.DB.First(&drama, "book_id = ?", c.Params("id")).Error; err != nil {
+		return c.Status(404).JSON(fiber.Map{"status": "error", "message": "Drama not found"})
+	}
+
+	result := classifier.Classify(drama.Judul, drama.Deskripsi)
+	return c.JSON(fiber.Map{"status": "success", "data": fiber.Map{
+		"labels":    result.Suggestions(),
+		"confident": result.Confident(),
+		"locked":    drama.GenresLocked,
+		"current":   models.DramaGenres(database.DB, drama.BookID),
+	}})
+}
+
+// lockGenres saves admin-chosen genres and stops the classifier touching the drama
+func lockGenres(bookID string, genres []string) error {
+	if err := models.SetDramaGenres(database.DB, bookID, models.GenreSourceManual, genres); err != nil {
+		return err
+	}
+	return database.DB.Model(&models.Drama{}).Where("book_id = ?", bookID).Update("genres_locked", true).Error
+}
+
+func resolveReview(review *models.GenreReview, status string) {
+	now := time.Now()
+	review.Status = status
+	review.ResolvedAt = &now
+	database.DB.Save(review)
+	models.LogInfo(database.DB, fmt.Sprintf("Genre review for %s %s", review.BookID, status))
+}
@@ -0,0 +1,182 @@
+package handlers
+
+import (
+	"dramabang/database"
+	"dramabang/models"
+	"dramabang/services/adapter"
+	"dramabang/services/suggest"
+	"dramabang/services/titles"
+	"fmt"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	// localSearchLimit caps results taken from the local index
+	localSearchLimit = 40
+	// healthyUpstreams is the share of providers that must answer for live results to lead
+	healthyUpstreams = 0.5
+	// suggestLimit is the default number of completions per kind
+	suggestLimit = 8
+)
+
+// Search result sources reported to clients
+const (
+	searchSourceLive    = "live"    // Upstreams healthy; local hits only fill in
+	searchSourceBlended = "blended" // Upstreams degraded; local and live interleaved
+	searchSourceLocal   = "local"   // No upstream answered
+	searchSourceFuzzy   = "fuzzy"   // Nothing matched exactly; typo-tolerant title matches
+)
+
+// GetSearch searches the live providers and the local catalog index, letting
+// upstream health decide how the two are combined (?q=, ?dubbed=)
+func GetSearch(c *fiber.Ctx) error {
+	q := c.Query("q", c.Query("query"))
+	if q == "" {
+		return c.JSON(fiber.Map{"status": "success", "data": []models.Drama{}})
+	}
+	started := time.Now()
+
+	live, health, err := AdapterManager.Search(requestLocale(c), q)
+	if err != nil {
+		return c.Status(502).JSON(fiber.Map{"error": "Search failed", "details": err.Error()})
+	}
+	local, err := models.SearchDramas(database.DB, q, localSearchLimit)
+	if err != nil {
+		fmt.Println("Local search failed:", err)
+	}
+
+	dramas, source := blendSearch(hideUnavailable(live), local, health)
+
+	var corrected string
+	if len(dramas) == 0 {
+		dramas, corrected = fuzzySearch(q)
+		source = searchSourceFuzzy
+	}
+	dramas = filterDubbed(c, dramas)
+
+	logSearch(c, q, len(dramas), source, corrected, health, len(local), started)
+	if len(dramas) > 0 {
+		if corrected != "" {
+			suggest.Default.RecordQuery(corrected)
+		} else {
+			suggest.Default.RecordQuery(q)
+		}
+	}
+
+	resp := fiber.Map{
+		"status":          "success",
+		"query":           q,
+		"total_results":   len(dramas),
+		"source":          source,
+		"upstream_health": health,
+		"data":            dramas,
+	}
+	if corrected != "" {
+		resp["corrected_query"] = corrected
+	}
+	return c.JSON(resp)
+}
+
+// fuzzySearch loads catalog dramas whose titles match the query despite typos,
+// with the corrected query when it differs from the original
+func fuzzySearch(q string) ([]models.Drama, string) {
+	matches, corrected := suggest.Default.Fuzzy(q, localSearchLimit)
+	if len(matches) == 0 {
+		return []models.Drama{}, ""
+	}
+
+	ids := make([]string, len(matches))
+	for i, m := range matches {
+		ids[i] = m.BookID
+	}
+	var rows []models.Drama
+	database.DB.Where("book_id IN ?", ids).Find(&rows)
+	byID := make(map[string]models.Drama, len(rows))
+	for _, d := range rows {
+		byID[d.BookID] = d
+	}
+	dramas := make([]models.Drama, 0, len(rows))
+	for _, id := range ids {
+		if d, ok := byID[id]; ok {
+			dramas = append(dramas, d)
+		}
+	}
+
+	if corrected == titles.Key(q) {
+		corrected = ""
+	}
+	return hideUnavailable(dramas), corrected
+}
+
+// GetSearchSuggest autocompletes titles and popular queries from the in-memory
+// index, falling back to typo-tolerant matches (?q=, ?limit=)
+func GetSearchSuggest(c *fiber.Ctx) error {
+	q := c.Query("q")
+	limit := c.QueryInt("limit", suggestLimit)
+	if limit < 1 || limit > 20 {
+		limit = suggestLimit
+	}
+
+	completions := suggest.Default.Complete(q, limit)
+	if len(completions) < limit {
+		seen := make(map[string]bool, len(completions))
+		for _, t := range completions {
+			seen[t.BookID] = true
+		}
+		matches, _ := suggest.Default.Fuzzy(q, limit)
+		for _, m := range matches {
+			if len(completions) == limit {
+				break
+			}
+			if !seen[m.BookID] {
+				completions = append(completions, m.Title)
+			}
+		}
+	}
+
+	return c.JSON(fiber.Map{
+		"status": "success",
+		"data": fiber.Map{
+			"titles":  completions,
+			"queries": suggest.Default.PopularQueries(q, limit),
+		},
+	})
+}
+
+// blendSearch combines live and local results without duplicates
+func blendSearch(live, local []models.Drama, health adapter.SearchHealth) ([]models.Drama, string) {
+	if len(live) == 0 || health.Answered == 0 {
+		return local, searchSourceLocal
+	}
+
+	seen := make(map[string]bool, len(live)+len(local))
+	out := make([]models.Drama, 0, len(live)+len(local))
+	add := func(d models.Drama) {
+		if !seen[d.BookID] {
+			seen[d.BookID] = true
+			out = append(out, d)
+		}
+	}
+
+	if health.Ratio() >= healthyUpstreams {
+		for _, d := range live {
+			add(d)
+		}
+		for _, d := range local {
+			add(d)
+		}
+		return out, searchSourceLive
+	}
+
+	for i := 0; i < len(live) || i < len(local); i++ {
+		if i < len(local) {
+			add(local[i])
+		}
+		if i < len(live) {
+			add(live[i])
+		}
+	}
+	return out, searchSourceBlended
+}
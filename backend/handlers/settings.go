@@ -3,11 +3,24 @@ package handlers
 import (
 	"dramabang/database"
 	"dramabang/models"
+	"dramabang/services/credentials"
 	"os"
 
 	"github.com/gofiber/fiber/v2"
 )
 
+// managedSettingKeys are JSON settings owned by a validating admin endpoint;
+// raw writes would skip validation and leave the in-memory copies stale
+var managedSettingKeys = map[string]string{
+	providerProxiesKey:  "/admin/proxies",
+	providerSettingsKey: "/admin/providers",
+	rateLimitsKey:       "/admin/ratelimits",
+	feedRulesKey:        "/admin/feeds/rules",
+	homeLayoutKey:       "/admin/home/layout",
+	homeHistoryKey:      "/admin/home/layout",
+	jobSchedulesKey:     "/admin/jobs/schedules",
+}
+
 // GetSettings retrieves all settings or a specific key
 func GetSettings(c *fiber.Ctx) error {
 	var settings []models.Setting
@@ -16,6 +29,14 @@ func GetSettings(c *fiber.Ctx) error {
 	// Convert to map for easier frontend consumption
 	settingMap := make(map[string]string)
 	for _, s := range settings {
+		// Vault entries are managed via /admin/credentials
+		if credentials.IsCredentialKey(s.Key) {
+			continue
+		}
+		if s.Key == providerProxiesKey {
+			settingMap[s.Key] = redactedProxyConfig()
+			continue
+		}
 		settingMap[s.Key] = s.Value
 	}
 
@@ -33,6 +54,13 @@ func UpdateSettings(c *fiber.Ctx) error {
 		return c.Status(400).JSON(fiber.Map{"status": "error", "message": "Invalid input"})
 	}
 
+	if credentials.IsCredentialKey(input.Key) {
+		return c.Status(400).JSON(fiber.Map{"status": "error", "message": "Use /admin/credentials to manage tokens"})
+	}
+	if endpoint, ok := managedSettingKeys[input.Key]; ok {
+		return c.Status(400).JSON(fiber.Map{"status": "error", "message": "Use " + endpoint + " to manage " + input.Key})
+	}
+
 	var setting models.Setting
 	// Check if exists
 	if err := database.DB.Where("key = ?", input.Key).First(&setting).Error; err != nil {
@@ -69,6 +97,13 @@ func UpdateSettingsBatch(c *fiber.Ctx) error {
 	tx := database.DB.Begin()
 
 	for k, v := range input {
+		if credentials.IsCredentialKey(k) {
+			continue // Managed via /admin/credentials
+		}
+		if _, ok := managedSettingKeys[k]; ok {
+			continue // Managed via its admin endpoint; the proxies view only holds a redacted copy
+		}
+
 		var setting models.Setting
 		if err := tx.Where("key = ?", k).First(&setting).Error; err != nil {
 			// Create
@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"bytes"
+	"compress/gzip"
+	"dramabang/services/sitemap"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// sitemapChildRe matches the child file names an index can point to
+var sitemapChildRe = regexp.MustCompile(`^sitemap-\d+\.xml$`)
+
+// GetSitemapData serves /sitemap.xml: a urlset, or an index once the catalog passes 50k URLs
+func GetSitemapData(c *fiber.Ctx) error {
+	return sendSitemap(c, sitemap.IndexFile)
+}
+
+// GetSitemapFile serves a child sitemap listed in the index (/sitemaps/sitemap-N.xml)
+func GetSitemapFile(c *fiber.Ctx) error {
+	name := c.Params("file")
+	if !sitemapChildRe.MatchString(name) {
+		return c.Status(404).JSON(fiber.Map{"status": "error", "message": "Sitemap not found"})
+	}
+	return sendSitemap(c, name+".gz")
+}
+
+// sendSitemap writes a stored gzip file, decompressing it for clients without gzip support
+func sendSitemap(c *fiber.Ctx, name string) error {
+	data, err := os.ReadFile(filepath.Join(sitemap.Dir, name))
+	if os.IsNotExist(err) {
+		if name == sitemap.IndexFile && JobScheduler != nil {
+			// First request after deploy: build it in the background
+			JobScheduler.Trigger("sitemap", "manual")
+		}
+		c.Set("Retry-After", "300")
+		return c.Status(503).JSON(fiber.Map{"status": "error", "message": "Sitemap is being generated"})
+	}
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"status": "error", "message": "Failed to read sitemap"})
+	}
+
+	c.Set(fiber.HeaderContentType, "application/xml; charset=utf-8")
+	c.Set(fiber.HeaderCacheControl, "public, max-age=3600")
+	c.Set(fiber.HeaderVary, fiber.HeaderAcceptEncoding)
+	if strings.Contains(c.Get(fiber.HeaderAcceptEncoding), "gzip") {
+		c.Set(fiber.HeaderContentEncoding, "gzip")
+		return c.Send(data)
+	}
+
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"status": "error", "message": "Failed to read sitemap"})
+	}
+	plain, err := io.ReadAll(zr)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"status": "error", "message": "Failed to read sitemap"})
+	}
+	return c.Send(plain)
+}
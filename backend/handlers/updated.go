@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"dramabang/database"
+	"dramabang/models"
+	"dramabang/services/events"
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// UpdatedDrama is a drama in the recently-updated feed with its latest episode jump
+type UpdatedDrama struct {
+	models.Drama
+	PrevEpisodes    int `json:"prev_episodes"`
+	CurrentEpisodes int `json:"current_episodes"`
+}
+
+// RegisterEventHooks wires in-process events to their default handlers
+func RegisterEventHooks() {
+	events.OnNewEpisodes(func(e events.NewEpisodes) {
+		// Stale detail would hide the new episodes for up to an hour
+		AdapterManager.InvalidateDrama(e.BookID)
+		models.LogInfo(database.DB, fmt.Sprintf("New episodes: %s (%d -> %d)", e.Judul, e.Previous, e.Current))
+	})
+}
+
+// GetUpdated lists dramas that recently gained episodes, newest first
+func GetUpdated(c *fiber.Ctx) error {
+	page := c.QueryInt("page", 1)
+	limit := c.QueryInt("limit", 20)
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 50 {
+		limit = 20
+	}
+
+	var dramas []models.Drama
+	if err := database.DB.
+		Where("episodes_updated_at IS NOT NULL").
+		Where("availability IS NULL OR availability <> ?", models.AvailabilityUnavailable).
+		Order("episodes_updated_at desc").
+		Limit(limit).Offset((page - 1) * limit).
+		Find(&dramas).Error; err != nil {
+		return c.Status(500).JSON(fiber.Map{"status": "error", "message": "Database error"})
+	}
+
+	// Latest snapshot of each drama carries the episode jump
+	ids := make([]string, len(dramas))
+	for i, d := range dramas {
+		ids[i] = d.BookID
+	}
+	var snaps []models.DramaSnapshot
+	database.DB.Where("id IN (?)", database.DB.Model(&models.DramaSnapshot{}).
+		Select("MAX(id)").Where("book_id IN ? AND prev_count > 0 AND episode_count > prev_count", ids).Group("book_id")).
+		Find(&snaps)
+	latest := make(map[string]models.DramaSnapshot, len(snaps))
+	for _, s := range snaps {
+		latest[s.BookID] = s
+	}
+
+	data := make([]UpdatedDrama, len(dramas))
+	for i, d := range dramas {
+		s := latest[d.BookID]
+		data[i] = UpdatedDrama{Drama: d, PrevEpisodes: s.PrevCount, CurrentEpisodes: s.EpisodeCount}
+	}
+
+	return c.JSON(fiber.Map{
+		"status": "success",
+		"type":   "updated",
+		"page":   page,
+		"data":   data,
+	})
+}
+
+// GetDramaHistory returns the snapshot time series for one drama (admin)
+func GetDramaHistory(c *fiber.Ctx) error {
+	var snaps []models.DramaSnapshot
+	if err := database.DB.Where("book_id = ?", c.Params("id")).Order("id asc").Find(&snaps).Error; err != nil {
+		return c.Status(500).JSON(fiber.Map{"status": "error", "message": "Database error"})
+	}
+	return c.JSON(fiber.Map{"status": "success", "data": snaps})
+}
@@ -4,6 +4,9 @@ import (
 	"dramabang/database"
 	"dramabang/handlers"
 	"dramabang/models"
+	"dramabang/services/adapter"
+	"dramabang/services/credentials"
+	"dramabang/services/suggest"
 	"log"
 	"os"
 	"time"
@@ -26,10 +29,13 @@ func main() {
 	// Security Middleware
 	app.Use(helmet.New()) // XSS, Clickjacking, etc.
 
-	// Rate Limiting (100 reqs / min)
+	// Rate Limiting (100 reqs / min); covers have their own limit below
 	app.Use(limiter.New(limiter.Config{
 		Max:        100,
 		Expiration: 1 * time.Minute,
+		Next: func(c *fiber.Ctx) bool {
+			return c.Path() == "/api/img"
+		},
 		KeyGenerator: func(c *fiber.Ctx) string {
 			return c.IP() // Limit by IP
 		},
@@ -51,6 +57,10 @@ func main() {
 	// In Docker, files are in public/uploads (mounted volume)
 	app.Static("/uploads", "public/uploads")
 
+	// SEO: generated by the sitemap job
+	app.Get("/sitemap.xml", handlers.GetSitemapData)
+	app.Get("/sitemaps/:file", handlers.GetSitemapFile)
+
 	// Database
 	database.Connect()
 	models.MigrateDramas(database.DB)
@@ -61,6 +71,45 @@ func main() {
 	// Migrate Comments & Bookmarks
 	database.DB.AutoMigrate(&models.Comment{})
 	models.MigrateBookmarks(database.DB)
+	models.MigrateMarkers(database.DB)
+	models.MigrateJobs(database.DB)
+	models.MigrateSnapshots(database.DB)
+	models.MigrateGenres(database.DB)
+	models.MigrateReviews(database.DB)
+	models.MigrateSearch(database.DB)
+	models.MigrateSearchLogs(database.DB)
+	models.MigrateBanners(database.DB)
+
+	// Provider credential vault (encrypted in settings)
+	credStore, err := credentials.Init(database.DB)
+	if err != nil {
+		log.Fatal("Failed to load credential vault: ", err)
+	}
+	adapter.Credentials = credStore
+	handlers.CredentialStore = credStore
+
+	// Outbound rate limits per provider / shared host
+	handlers.LoadRateLimits()
+
+	// Optional outbound proxies per provider (HTTP / SOCKS5)
+	handlers.LoadProxies()
+
+	// Provider enabled flags & merge priority
+	handlers.LoadProviderSettings()
+
+	// Trending / latest composition rules
+	handlers.LoadFeedRules()
+
+	// Server-driven homepage sections
+	handlers.LoadHomeLayout()
+
+	// Background jobs (ingest, dedup, classify, cleanup) with cron schedules
+	handlers.InitJobs()
+	handlers.RegisterEventHooks()
+
+	// In-memory title index for autocomplete and typo-tolerant search
+	suggest.Default.StartRefresh(database.DB, suggest.RefreshInterval)
+	handlers.SeedPopularQueries()
 
 	// FORCE MANUAL MIGRATION as Fallback
 	// Ensure table exists for postgres (since AutoMigrate is sometimes flaky on new tables in live envs)
@@ -79,18 +128,41 @@ func main() {
 	log.Println("Starting server on :3000...")
 
 	// Routes
+	// Image Rate Limiter (1000 / min): a grid page alone loads dozens of covers
+	imageLimiter := limiter.New(limiter.Config{
+		Max:        1000,
+		Expiration: 1 * time.Minute,
+		KeyGenerator: func(c *fiber.Ctx) string {
+			return c.IP()
+		},
+		LimitReached: func(c *fiber.Ctx) error {
+			return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
+				"status":  "error",
+				"message": "Too many requests, please try again later.",
+			})
+		},
+	})
+
 	api := app.Group("/api")
 
 	api.Get("/trending", handlers.GetTrending)
 	api.Get("/latest", handlers.GetLatest)
+	api.Get("/updated", handlers.GetUpdated)                          // Dramas that recently gained episodes
 	api.Get("/provider/:provider/latest", handlers.GetProviderLatest) // New Provider-specific Route
 	api.Get("/search", handlers.GetSearch)
+	api.Get("/search/suggest", handlers.GetSearchSuggest) // Autocomplete from the in-memory title index
+	api.Get("/search/popular", handlers.GetPopularSearches)
 	api.Get("/detail", handlers.GetDetail)
 	api.Get("/stream", handlers.GetStream)
+	api.Get("/stream/batch", handlers.GetStreamBatch)
 	api.Get("/random", handlers.GetRandom)
+	api.Get("/categories", handlers.GetCategories)     // Genre taxonomy with counts
+	api.Get("/categories/:slug", handlers.GetCategory) // Dramas in one genre
+	api.Get("/home", handlers.GetHome)                 // Ordered homepage sections from the admin layout
 	api.Get("/hero", handlers.GetHero)
+	api.Post("/hero/:id/click", handlers.TrackBannerClick) // Counts a banner click
+	api.Get("/img", imageLimiter, handlers.GetImage)       // Self-hosted image proxy (covers)
 	api.Get("/settings", handlers.GetPublicSettings)
-	api.Get("/sitemap", handlers.GetSitemapData)
 	api.Post("/auth/google", handlers.VerifyGoogleToken)
 	api.Post("/auth/login", handlers.LocalLogin)
 	api.Get("/auth/verify", handlers.VerifyEmail)        // New Verification Endpoint
@@ -156,9 +228,25 @@ func main() {
 	admin.Put("/dramas/:id", handlers.UpdateDrama)
 	admin.Put("/dramas/:id/feature", handlers.ToggleFeatured)
 	admin.Delete("/dramas/:id", handlers.DeleteDrama)
+	admin.Get("/dramas/:id/history", handlers.GetDramaHistory)
+	admin.Put("/dramas/:id/genres", handlers.SetDramaGenres)
+	admin.Get("/dramas/:id/classify", handlers.ExplainClassification)
 	admin.Post("/action/ingest", handlers.TriggerIngest)
 	admin.Post("/action/dedup", handlers.TriggerDedup)
 	admin.Get("/logs", handlers.GetSystemLogs)
+	admin.Get("/analytics/search", handlers.GetSearchAnalytics) // Top, trending and zero-result queries
+
+	// Hero Banners
+	admin.Get("/banners", handlers.GetBanners)
+	admin.Post("/banners", handlers.CreateBanner)
+	admin.Put("/banners/order", handlers.ReorderBanners)
+	admin.Put("/banners/:id", handlers.UpdateBanner)
+	admin.Delete("/banners/:id", handlers.DeleteBanner)
+
+	// Skip Markers (intro/outro)
+	admin.Get("/markers/:bookId", handlers.GetSkipMarkers)
+	admin.Put("/markers", handlers.SaveSkipMarker)
+	admin.Delete("/markers/:id", handlers.DeleteSkipMarker)
 
 	// Settings
 	admin.Get("/settings", handlers.GetSettings)
@@ -167,6 +255,51 @@ func main() {
 	admin.Post("/upload", handlers.UploadFile)         // Secured under /admin group
 	admin.Put("/account", handlers.UpdateAdminAccount) // Change Admin Password
 
+	// Provider Credentials (upstream API tokens)
+	admin.Get("/credentials", handlers.GetCredentials)
+	admin.Post("/credentials", handlers.AddCredential)
+	admin.Delete("/credentials/:id", handlers.RevokeCredential)
+
+	// Outbound Rate Limits & Quotas
+	admin.Get("/ratelimits", handlers.GetRateLimits)
+	admin.Put("/ratelimits", handlers.UpdateRateLimits)
+
+	// Outbound Proxies & Provider Metrics
+	admin.Get("/proxies", handlers.GetProxies)
+	admin.Put("/proxies", handlers.UpdateProxies)
+
+	// Provider Console
+	admin.Get("/providers", handlers.GetProviders)
+	admin.Put("/providers/:id", handlers.UpdateProvider)
+	admin.Post("/providers/:id/test", handlers.TestProvider)
+
+	// Genre Taxonomy & classifier review queue
+	admin.Get("/genres/reviews", handlers.GetGenreReviews)
+	admin.Post("/genres/reviews/:id/approve", handlers.ApproveGenreReview)
+	admin.Post("/genres/reviews/:id/reject", handlers.RejectGenreReview)
+	admin.Get("/genres", handlers.GetGenres)
+	admin.Post("/genres", handlers.CreateGenre)
+	admin.Put("/genres/:id", handlers.UpdateGenre)
+	admin.Delete("/genres/:id", handlers.DeleteGenre)
+	admin.Post("/genres/:id/merge", handlers.MergeGenre)
+
+	// Feed Composition
+	admin.Get("/feeds/rules", handlers.GetFeedRules)
+	admin.Put("/feeds/rules", handlers.UpdateFeedRules)
+	admin.Post("/feeds/preview", handlers.PreviewFeed)
+	admin.Get("/home/layout", handlers.GetHomeLayout)
+	admin.Put("/home/layout", handlers.UpdateHomeLayout)
+	admin.Get("/home/layout/history", handlers.GetHomeLayoutHistory)
+	admin.Post("/home/layout/rollback", handlers.RollbackHomeLayout)
+
+	// Background Jobs
+	admin.Get("/jobs", handlers.GetJobs)
+	admin.Post("/jobs", handlers.RunJob)
+	admin.Get("/jobs/schedules", handlers.GetJobSchedules)
+	admin.Put("/jobs/schedules", handlers.UpdateJobSchedules)
+	admin.Get("/jobs/:id", handlers.GetJob)
+	admin.Post("/jobs/:id/cancel", handlers.CancelJob)
+
 	// User Admin
 	// User Admin (Protected)
 	admin.Get("/users", handlers.GetAdminUsers)
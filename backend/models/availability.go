@@ -0,0 +1,112 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Availability values; an empty string means available
+const (
+	AvailabilityUnstable    = "unstable"
+	AvailabilityUnavailable = "unavailable"
+)
+
+// A drama is hidden once it has failed this many times in a row over at least UnavailableAfter
+const (
+	UnavailableFailures = 3
+	UnavailableAfter    = 48 * time.Hour
+)
+
+// MarkSeen records that the upstream still serves these dramas and clears any failure streak
+func MarkSeen(db *gorm.DB, bookIDs ...string) {
+	if len(bookIDs) == 0 {
+		return
+	}
+	db.Model(&Drama{}).Where("book_id IN ?", bookIDs).Updates(map[string]interface{}{
+		"last_seen_at":      time.Now(),
+		"failure_count":     0,
+		"availability":      "",
+		"unavailable_since": nil,
+	})
+}
+
+// MarkSeenIfStale is MarkSeen for one drama, skipped when it was seen within
+// interval and isn't flagged
+func MarkSeenIfStale(db *gorm.DB, bookID string, interval time.Duration) {
+	db.Model(&Drama{}).
+		Where("book_id = ?", bookID).
+		Where("last_seen_at IS NULL OR last_seen_at < ? OR failure_count > 0", time.Now().Add(-interval)).
+		Updates(map[string]interface{}{
+			"last_seen_at":      time.Now(),
+			"failure_count":     0,
+			"availability":      "",
+			"unavailable_since": nil,
+		})
+}
+
+// MarkFailure records a failed lookup and flips the drama to unavailable once
+// the streak is long enough. Unknown dramas are ignored.
+func MarkFailure(db *gorm.DB, bookID string) {
+	var drama Drama
+	if err := db.Select("book_id", "failure_count", "unavailable_since").Where("book_id = ?", bookID).First(&drama).Error; err != nil {
+		return
+	}
+
+	now := time.Now()
+	since := now
+	if drama.UnavailableSince != nil {
+		since = *drama.UnavailableSince
+	}
+	failures := drama.FailureCount + 1
+
+	status := AvailabilityUnstable
+	if failures >= UnavailableFailures && now.Sub(since) >= UnavailableAfter {
+		status = AvailabilityUnavailable
+	}
+
+	db.Model(&Drama{}).Where("book_id = ?", bookID).Updates(map[string]interface{}{
+		"failure_count":     failures,
+		"availability":      status,
+		"unavailable_since": since,
+	})
+}
+
+// IsUnavailable reports whether the drama has been delisted upstream
+func (d Drama) IsUnavailable() bool {
+	return d.Availability == AvailabilityUnavailable
+}
+
+// UnavailableIDs returns which of bookIDs are marked unavailable
+func UnavailableIDs(db *gorm.DB, bookIDs []string) map[string]bool {
+	hidden := make(map[string]bool)
+	if len(bookIDs) == 0 {
+		return hidden
+	}
+	var ids []string
+	db.Model(&Drama{}).Where("book_id IN ? AND availability = ?", bookIDs, AvailabilityUnavailable).Pluck("book_id", &ids)
+	for _, id := range ids {
+		hidden[id] = true
+	}
+	return hidden
+}
+
+// FindAlternates suggests the same title from other providers that are still
+// available, preferring the same dub/sub variant
+func FindAlternates(db *gorm.DB, drama Drama, limit int) []Drama {
+	query := db.Where("book_id <> ? AND (availability IS NULL OR availability <> ?)", drama.BookID, AvailabilityUnavailable)
+	if drama.TitleKey != "" {
+		query = query.Where("title_key = ?", drama.TitleKey).
+			Order(gorm.Expr("CASE WHEN dubbed = ? THEN 0 ELSE 1 END", drama.Dubbed))
+	} else {
+		title := strings.TrimSpace(drama.Judul)
+		if title == "" {
+			return nil
+		}
+		query = query.Where("LOWER(judul) = ?", strings.ToLower(title))
+	}
+	var alts []Drama
+	query.Limit(limit).Find(&alts)
+	return alts
+}
@@ -0,0 +1,83 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Banner is one admin-managed hero slot. It points at a drama, or at a custom
+// image and link when BookID is empty.
+type Banner struct {
+	ID            uint       `json:"id" gorm:"primaryKey"`
+	BookID        string     `json:"bookId" gorm:"index"`
+	Title         string     `json:"title"`    // Overrides the drama title
+	Subtitle      string     `json:"subtitle"` // Overrides the drama description
+	Image         string     `json:"image"`    // Uploaded via /api/admin/upload; overrides the cover
+	Link          string     `json:"link"`     // Custom target; defaults to the drama page
+	Position      int        `json:"position" gorm:"index"`
+	Locales       []string   `json:"locales" gorm:"serializer:json"` // Empty targets every locale
+	StartsAt      *time.Time `json:"starts_at"`
+	EndsAt        *time.Time `json:"ends_at"`
+	Enabled       bool       `json:"enabled"`
+	Clicks        int64      `json:"clicks"`
+	LastClickedAt *time.Time `json:"last_clicked_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+func MigrateBanners(db *gorm.DB) error {
+	return db.AutoMigrate(&Banner{})
+}
+
+// Banner.Status values, derived from Enabled and the schedule
+const (
+	BannerActive    = "active"
+	BannerScheduled = "scheduled"
+	BannerExpired   = "expired"
+	BannerDisabled  = "disabled"
+)
+
+// Status reports where the banner is in its schedule at t
+func (b Banner) Status(t time.Time) string {
+	switch {
+	case !b.Enabled:
+		return BannerDisabled
+	case b.StartsAt != nil && t.Before(*b.StartsAt):
+		return BannerScheduled
+	case b.EndsAt != nil && !t.Before(*b.EndsAt):
+		return BannerExpired
+	}
+	return BannerActive
+}
+
+// Targets reports whether the banner is shown for a locale
+func (b Banner) Targets(locale string) bool {
+	if len(b.Locales) == 0 {
+		return true
+	}
+	for _, l := range b.Locales {
+		if l == locale {
+			return true
+		}
+	}
+	return false
+}
+
+// ActiveBanners returns banners live at t for a locale, in display order
+func ActiveBanners(db *gorm.DB, locale string, t time.Time) []Banner {
+	var banners []Banner
+	db.Where("enabled = ?", true).
+		Where("starts_at IS NULL OR starts_at <= ?", t).
+		Where("ends_at IS NULL OR ends_at > ?", t).
+		Order("position, id").
+		Find(&banners)
+
+	active := make([]Banner, 0, len(banners))
+	for _, b := range banners {
+		if b.Targets(locale) {
+			active = append(active, b)
+		}
+	}
+	return active
+}
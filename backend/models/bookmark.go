@@ -11,6 +11,10 @@ type Bookmark struct {
 	// Relationship
 	User  User  `gorm:"foreignKey:UserID" json:"-"`
 	Drama Drama `gorm:"foreignKey:BookID;references:BookID" json:"drama"`
+
+	// Set when the drama was delisted upstream
+	Unavailable bool    `gorm:"-" json:"unavailable"`
+	Alternates  []Drama `gorm:"-" json:"alternates,omitempty"`
 }
 
 func MigrateBookmarks(db *gorm.DB) error {
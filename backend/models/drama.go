@@ -1,6 +1,10 @@
 package models
 
-import "gorm.io/gorm"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
 
 type Drama struct {
 	BookID       string `gorm:"primaryKey" json:"bookId"`
@@ -12,6 +16,37 @@ type Drama struct {
 	Genre        string `json:"genre"`
 	IsFeatured   bool   `json:"isFeatured"`
 
+	// Typed metadata filled by each adapter's Normalize (see metadata.go)
+	EpisodeCount int        `gorm:"index" json:"episode_count"`
+	LikesCount   int64      `json:"likes_count"`
+	ViewsCount   int64      `json:"views_count"`
+	ReleasedAt   *time.Time `json:"released_at,omitempty"`           // Upstream release date, when the provider sends one
+	Language     string     `gorm:"index" json:"language,omitempty"` // One of adapter.SupportedLocales
+	Dubbed       bool       `json:"dubbed"`
+	SeriesStatus string     `gorm:"index" json:"series_status,omitempty"` // "ongoing", "completed" or "" if unknown
+
+	// Canonical title without variant markers (see services/titles)
+	CanonicalTitle string `json:"canonical_title,omitempty"`
+	TitleKey       string `gorm:"index" json:"-"`
+	AudioLanguage  string `json:"audio_language,omitempty"` // Dub language when the title names one
+
+	// Taxonomy (see genre.go); Genre above is the comma-joined display form
+	Genres       []Genre  `gorm:"-" json:"genres,omitempty"`
+	Tags         []string `gorm:"-" json:"-"`    // Raw upstream tags, synced to genres by ingest
+	GenresLocked bool     `json:"genres_locked"` // Set when an admin edits genres; the classifier then skips the drama
+
+	// Availability tracking (see availability.go)
+	Availability     string     `gorm:"index" json:"availability,omitempty"` // "", "unstable" or "unavailable"
+	LastSeenAt       *time.Time `json:"last_seen_at,omitempty"`
+	FailureCount     int        `json:"failure_count,omitempty"`
+	UnavailableSince *time.Time `json:"unavailable_since,omitempty"` // First failure of the current streak
+
+	// First stored; rows older than this column are stamped at migration
+	CreatedAt *time.Time `gorm:"index" json:"created_at,omitempty"`
+
+	// Set by ingest when the episode count goes up (see snapshot.go)
+	EpisodesUpdatedAt *time.Time `gorm:"index" json:"episodes_updated_at,omitempty"`
+
 	// Relations
 	Episodes []Episode `gorm:"foreignKey:BookID;references:BookID" json:"episodes,omitempty"`
 }
@@ -57,6 +92,28 @@ type DetailResponse struct {
 type StreamResponse struct {
 	Status string     `json:"status"`
 	Data   StreamData `json:"data"`
+
+	// Navigation (nil when the episode list could not be loaded)
+	PrevIndex    *int        `json:"prev_index"`
+	NextIndex    *int        `json:"next_index"`
+	TotalEpisode int         `json:"total_episode"`
+	EpisodeTitle string      `json:"episode_title"`
+	Duration     int         `json:"duration"`
+	Markers      *SkipMarker `json:"markers,omitempty"`
+}
+
+type StreamBatchResponse struct {
+	Status string            `json:"status"`
+	BookID string            `json:"bookId"`
+	Data   []StreamBatchItem `json:"data"`
+}
+
+// StreamBatchItem is the per-episode result of a batch stream lookup
+type StreamBatchItem struct {
+	Index  int         `json:"index"`
+	Status string      `json:"status"` // "success", "error"
+	Data   *StreamData `json:"data,omitempty"`
+	Error  string      `json:"error,omitempty"`
 }
 
 type StreamData struct {
@@ -78,5 +135,8 @@ type VideoData struct {
 
 // MigrateDramas migrates the table
 func MigrateDramas(db *gorm.DB) error {
-	return db.AutoMigrate(&Drama{}, &Episode{})
+	if err := db.AutoMigrate(&Drama{}, &Episode{}); err != nil {
+		return err
+	}
+	return db.Model(&Drama{}).Where("created_at IS NULL").Update("created_at", time.Now()).Error
 }
@@ -0,0 +1,256 @@
+package models
+
+import (
+	"dramabang/services/titles"
+	"sort"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DramaGenre.Source values, in display priority order
+const (
+	GenreSourceManual     = "manual"     // Set by an admin
+	GenreSourceUpstream   = "upstream"   // Tags supplied by the provider
+	GenreSourceClassifier = "classifier" // Assigned by the classify job
+)
+
+var genreSourceRank = map[string]int{GenreSourceManual: 0, GenreSourceUpstream: 1, GenreSourceClassifier: 2}
+
+// Genre is one entry of the tag taxonomy
+type Genre struct {
+	ID        uint              `json:"id" gorm:"primaryKey"`
+	Slug      string            `json:"slug" gorm:"uniqueIndex;not null"`
+	Name      string            `json:"name"`                           // Site locale display name
+	Names     map[string]string `json:"names" gorm:"serializer:json"`   // Other locales, e.g. {"en": "Revenge"}
+	Curated   bool              `json:"curated"`                        // Seeded or created by an admin rather than learnt from upstream tags
+	Hidden    bool              `json:"hidden"`                         // Kept for filtering but not listed publicly
+	Count     int64             `json:"drama_count,omitempty" gorm:"-"` // Filled by the admin list
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+// DramaGenre links a drama to a genre
+type DramaGenre struct {
+	BookID    string    `json:"bookId" gorm:"primaryKey"`
+	GenreID   uint      `json:"genre_id" gorm:"primaryKey;index"`
+	Source    string    `json:"source"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// curatedGenres seeds the taxonomy with the classifier's categories
+var curatedGenres = []Genre{
+	{Name: "Epic Fantasy & Mythology", Names: map[string]string{"en": "Epic Fantasy & Mythology"}},
+	{Name: "Martial Arts Action", Names: map[string]string{"en": "Martial Arts Action"}},
+	{Name: "Historical Romance", Names: map[string]string{"en": "Historical Romance"}},
+	{Name: "Palace Intrigue & Politics", Names: map[string]string{"en": "Palace Intrigue & Politics"}},
+	{Name: "Modern Romance & CEO", Names: map[string]string{"en": "Modern Romance & CEO"}},
+	{Name: "School & Youth", Names: map[string]string{"en": "School & Youth"}},
+	{Name: "Mystery & Detective", Names: map[string]string{"en": "Mystery & Detective"}},
+	{Name: "E-Sports & Gaming", Names: map[string]string{"en": "E-Sports & Gaming"}},
+}
+
+// MigrateGenres migrates the taxonomy tables and seeds curated genres
+func MigrateGenres(db *gorm.DB) error {
+	if err := db.AutoMigrate(&Genre{}, &DramaGenre{}); err != nil {
+		return err
+	}
+	for _, g := range curatedGenres {
+		g.Slug = Slugify(g.Name)
+		g.Curated = true
+		db.Clauses(clause.OnConflict{DoNothing: true}).Create(&g)
+	}
+	return nil
+}
+
+// Slugify turns a display name into a URL slug ("Modern Romance & CEO" -> "modern-romance-ceo")
+func Slugify(name string) string {
+	return strings.ReplaceAll(titles.Key(name), " ", "-")
+}
+
+// DisplayName returns the name for a locale, falling back to the default name
+func (g Genre) DisplayName(locale string) string {
+	if n := g.Names[locale]; n != "" {
+		return n
+	}
+	return g.Name
+}
+
+// EnsureGenre finds a genre by the slug of name, creating it if missing
+func EnsureGenre(db *gorm.DB, name string, curated bool) (*Genre, error) {
+	slug := Slugify(name)
+	var g Genre
+	if err := db.Where("slug = ?", slug).First(&g).Error; err == nil {
+		return &g, nil
+	}
+	g = Genre{Slug: slug, Name: strings.TrimSpace(name), Names: map[string]string{}, Curated: curated}
+	if err := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&g).Error; err != nil {
+		return nil, err
+	}
+	if g.ID == 0 {
+		// Created concurrently
+		if err := db.Where("slug = ?", slug).First(&g).Error; err != nil {
+			return nil, err
+		}
+	}
+	return &g, nil
+}
+
+// SetDramaGenres replaces a drama's links from one source with the named genres.
+// Links from other sources are kept; Drama.Genre is refreshed afterwards.
+func SetDramaGenres(db *gorm.DB, bookID, source string, names []string) error {
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("book_id = ? AND source = ?", bookID, source).Delete(&DramaGenre{}).Error; err != nil {
+			return err
+		}
+		seen := make(map[uint]bool)
+		for _, name := range names {
+			if Slugify(name) == "" {
+				continue
+			}
+			g, err := EnsureGenre(tx, name, source == GenreSourceManual)
+			if err != nil {
+				return err
+			}
+			if seen[g.ID] {
+				continue
+			}
+			seen[g.ID] = true
+			// A higher-priority source may already link this genre
+			link := DramaGenre{BookID: bookID, GenreID: g.ID, Source: source}
+			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&link).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return RefreshGenreString(db, bookID)
+}
+
+// RefreshGenreString rewrites the legacy Drama.Genre column as the comma-separated
+// names of the drama's genres, manual first, then upstream, then classifier
+func RefreshGenreString(db *gorm.DB, bookID string) error {
+	genres := DramaGenres(db, bookID)
+	names := make([]string, len(genres))
+	for i, g := range genres {
+		names[i] = g.Name
+	}
+	return db.Model(&Drama{}).Where("book_id = ?", bookID).Update("genre", strings.Join(names, ", ")).Error
+}
+
+// DramaGenres returns a drama's genres in display priority order
+func DramaGenres(db *gorm.DB, bookID string) []Genre {
+	byBook := genresFor(db, []string{bookID})
+	return byBook[bookID]
+}
+
+// LoadGenres fills Drama.Genres for a page of dramas
+func LoadGenres(db *gorm.DB, dramas []Drama) {
+	if len(dramas) == 0 {
+		return
+	}
+	ids := make([]string, len(dramas))
+	for i, d := range dramas {
+		ids[i] = d.BookID
+	}
+	byBook := genresFor(db, ids)
+	for i := range dramas {
+		dramas[i].Genres = byBook[dramas[i].BookID]
+	}
+}
+
+func genresFor(db *gorm.DB, bookIDs []string) map[string][]Genre {
+	var links []DramaGenre
+	db.Where("book_id IN ?", bookIDs).Find(&links)
+	if len(links) == 0 {
+		return nil
+	}
+
+	genreIDs := make([]uint, 0, len(links))
+	for _, l := range links {
+		genreIDs = append(genreIDs, l.GenreID)
+	}
+	var genres []Genre
+	db.Where("id IN ?", genreIDs).Find(&genres)
+	byID := make(map[uint]Genre, len(genres))
+	for _, g := range genres {
+		byID[g.ID] = g
+	}
+
+	sort.SliceStable(links, func(i, j int) bool {
+		if links[i].Source != links[j].Source {
+			return genreSourceRank[links[i].Source] < genreSourceRank[links[j].Source]
+		}
+		return links[i].CreatedAt.Before(links[j].CreatedAt)
+	})
+	out := make(map[string][]Genre)
+	for _, l := range links {
+		if g, ok := byID[l.GenreID]; ok {
+			out[l.BookID] = append(out[l.BookID], g)
+		}
+	}
+	return out
+}
+
+// WithGenre restricts a drama query to those tagged with the exact slug
+func WithGenre(query *gorm.DB, slug string) *gorm.DB {
+	sub := query.Session(&gorm.Session{NewDB: true}).Model(&DramaGenre{}).Select("drama_genres.book_id").
+		Joins("JOIN genres ON genres.id = drama_genres.genre_id").
+		Where("genres.slug = ?", slug)
+	return query.Where("book_id IN (?)", sub)
+}
+
+// MergeGenre moves every link of genre from onto genre into and deletes from.
+// Used to fold noisy upstream tags into curated genres.
+func MergeGenre(db *gorm.DB, from, into uint) error {
+	var bookIDs []string
+	db.Model(&DramaGenre{}).Where("genre_id = ?", from).Pluck("book_id", &bookIDs)
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var links []DramaGenre
+		if err := tx.Where("genre_id = ?", from).Find(&links).Error; err != nil {
+			return err
+		}
+		for _, l := range links {
+			l.GenreID = into
+			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&l).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Where("genre_id = ?", from).Delete(&DramaGenre{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&Genre{}, from).Error
+	})
+	if err != nil {
+		return err
+	}
+	for _, id := range bookIDs {
+		RefreshGenreString(db, id)
+	}
+	return nil
+}
+
+// DeleteGenre removes a genre and its links
+func DeleteGenre(db *gorm.DB, id uint) error {
+	var bookIDs []string
+	db.Model(&DramaGenre{}).Where("genre_id = ?", id).Pluck("book_id", &bookIDs)
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("genre_id = ?", id).Delete(&DramaGenre{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&Genre{}, id).Error
+	})
+	if err != nil {
+		return err
+	}
+	for _, bookID := range bookIDs {
+		RefreshGenreString(db, bookID)
+	}
+	return nil
+}
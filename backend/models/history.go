@@ -15,6 +15,10 @@ type UserHistory struct {
 
 	// Relationship (Optional if you want to preload)
 	Drama Drama `json:"drama" gorm:"foreignKey:BookID;references:BookID"`
+
+	// Set when the drama was delisted upstream
+	Unavailable bool    `json:"unavailable" gorm:"-"`
+	Alternates  []Drama `json:"alternates,omitempty" gorm:"-"`
 }
 
 func MigrateHistory(db *gorm.DB) {
@@ -0,0 +1,125 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// HomeSection.Source values: where a section's dramas come from
+const (
+	HomeSourceFeatured         = "featured"          // Hero banners, featured dramas, trending fallback
+	HomeSourceTrending         = "trending"          // Merged trending feed
+	HomeSourceLatest           = "latest"            // Merged latest feed
+	HomeSourceProviderLatest   = "provider_latest"   // One provider's latest feed (Provider)
+	HomeSourceGenre            = "genre"             // Catalog dramas in one genre (Genre slug)
+	HomeSourceContinueWatching = "continue_watching" // Signed-in user's history; hidden for guests
+	HomeSourceRecommendations  = "recommendations"   // Unwatched dramas from the user's genres, popular ones for guests
+)
+
+var homeSources = map[string]bool{
+	HomeSourceFeatured: true, HomeSourceTrending: true, HomeSourceLatest: true, HomeSourceProviderLatest: true,
+	HomeSourceGenre: true, HomeSourceContinueWatching: true, HomeSourceRecommendations: true,
+}
+
+// HomeSection.Type values: how the client renders a section
+var homeSectionTypes = map[string]bool{"hero": true, "carousel": true, "grid": true}
+
+const (
+	// DefaultHomeLimit applies when a section has no limit
+	DefaultHomeLimit = 12
+	// MaxHomeLimit caps items per section
+	MaxHomeLimit = 50
+)
+
+// HomeSection is one rail of the homepage
+type HomeSection struct {
+	ID       string            `json:"id"`     // Stable key clients can use for state
+	Type     string            `json:"type"`   // hero, carousel or grid
+	Title    string            `json:"title"`  // Site locale title
+	Titles   map[string]string `json:"titles"` // Other locales, e.g. {"en": "New Releases"}
+	Source   string            `json:"source"`
+	Provider string            `json:"provider,omitempty"` // For provider_latest
+	Genre    string            `json:"genre,omitempty"`    // Genre slug for genre
+	Limit    int               `json:"limit"`
+	Hidden   bool              `json:"hidden"` // Kept in the layout but not served
+}
+
+// HomeLayout is one saved version of the homepage
+type HomeLayout struct {
+	Version   int           `json:"version"`
+	Sections  []HomeSection `json:"sections"`
+	Note      string        `json:"note,omitempty"` // What changed, for the history list
+	UpdatedAt time.Time     `json:"updated_at"`
+}
+
+// DefaultHomeLayout mirrors the rails the web homepage has always shown
+var DefaultHomeLayout = HomeLayout{
+	Version: 0,
+	Sections: []HomeSection{
+		{ID: "hero", Type: "hero", Title: "Featured", Source: HomeSourceFeatured, Limit: 5},
+		{ID: "continue-watching", Type: "carousel", Title: "Lanjutkan Menonton", Titles: map[string]string{"en": "Continue Watching"}, Source: HomeSourceContinueWatching, Limit: 10},
+		{ID: "trending", Type: "carousel", Title: "Trending di DramaPlay", Titles: map[string]string{"en": "Trending in DramaPlay"}, Source: HomeSourceTrending, Limit: 10},
+		{ID: "latest", Type: "grid", Title: "Rilis Terbaru", Titles: map[string]string{"en": "New Releases"}, Source: HomeSourceLatest, Limit: 12},
+		{ID: "recommended", Type: "grid", Title: "Rekomendasi", Titles: map[string]string{"en": "Recommended"}, Source: HomeSourceRecommendations, Limit: 12},
+		{ID: "melolo", Type: "carousel", Title: "Melolo Dramas", Source: HomeSourceProviderLatest, Provider: "melolo", Limit: 12},
+		{ID: "starshort", Type: "carousel", Title: "Starshort Originals", Source: HomeSourceProviderLatest, Provider: "starshort", Limit: 12},
+		{ID: "freeshort", Type: "carousel", Title: "FreeShort Dramas", Source: HomeSourceProviderLatest, Provider: "freeshort", Limit: 12},
+		{ID: "shortmax", Type: "carousel", Title: "ShortMax Originals", Source: HomeSourceProviderLatest, Provider: "shortmax", Limit: 12},
+		{ID: "dramadash", Type: "carousel", Title: "DramaDash Hits", Source: HomeSourceProviderLatest, Provider: "dramadash", Limit: 12},
+		{ID: "hishort", Type: "carousel", Title: "HiShort Dramas", Source: HomeSourceProviderLatest, Provider: "hishort", Limit: 12},
+		{ID: "flickreels", Type: "carousel", Title: "FlickReels Dramas", Source: HomeSourceProviderLatest, Provider: "flickreels", Limit: 12},
+		{ID: "dramawave", Type: "carousel", Title: "DramaWave Dramas", Source: HomeSourceProviderLatest, Provider: "dramawave", Limit: 12},
+		{ID: "romance", Type: "grid", Title: "Romansa Modern & CEO", Titles: map[string]string{"en": "Modern Romance & CEO"}, Source: HomeSourceGenre, Genre: "modern-romance-ceo", Limit: 12},
+		{ID: "action", Type: "grid", Title: "Aksi Bela Diri", Titles: map[string]string{"en": "Martial Arts Action"}, Source: HomeSourceGenre, Genre: "martial-arts-action", Limit: 12},
+	},
+}
+
+// DisplayTitle returns the title for a locale, falling back to the default title
+func (s HomeSection) DisplayTitle(locale string) string {
+	if t := s.Titles[locale]; t != "" {
+		return t
+	}
+	return s.Title
+}
+
+// Validate checks section fields and fills in default limits. Provider IDs are
+// checked by the caller, which knows the registered providers.
+func (l *HomeLayout) Validate() error {
+	if len(l.Sections) == 0 {
+		return errors.New("layout needs at least one section")
+	}
+	ids := make(map[string]bool, len(l.Sections))
+	for i := range l.Sections {
+		s := &l.Sections[i]
+		if s.ID == "" {
+			return fmt.Errorf("section %d: id is required", i+1)
+		}
+		if ids[s.ID] {
+			return fmt.Errorf("section %s: duplicate id", s.ID)
+		}
+		ids[s.ID] = true
+		if !homeSectionTypes[s.Type] {
+			return fmt.Errorf("section %s: unknown type %q", s.ID, s.Type)
+		}
+		if !homeSources[s.Source] {
+			return fmt.Errorf("section %s: unknown source %q", s.ID, s.Source)
+		}
+		if s.Source == HomeSourceProviderLatest && s.Provider == "" {
+			return fmt.Errorf("section %s: provider is required", s.ID)
+		}
+		if s.Source == HomeSourceGenre && s.Genre == "" {
+			return fmt.Errorf("section %s: genre is required", s.ID)
+		}
+		if s.Limit == 0 {
+			s.Limit = DefaultHomeLimit
+		}
+		if s.Limit < 1 || s.Limit > MaxHomeLimit {
+			return fmt.Errorf("section %s: limit must be between 1 and %d", s.ID, MaxHomeLimit)
+		}
+		if s.Titles == nil {
+			s.Titles = map[string]string{}
+		}
+	}
+	return nil
+}
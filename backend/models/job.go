@@ -0,0 +1,91 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Job statuses
+const (
+	JobQueued    = "queued"
+	JobRunning   = "running"
+	JobSucceeded = "succeeded"
+	JobFailed    = "failed"
+	JobCancelled = "cancelled"
+)
+
+// Job is one run of a background task (ingest, dedup, classify, cleanup)
+type Job struct {
+	ID         uint       `json:"id" gorm:"primaryKey"`
+	Type       string     `json:"type" gorm:"index;not null"`
+	Status     string     `json:"status" gorm:"index"`
+	Trigger    string     `json:"trigger"`  // "manual" or "schedule"
+	Progress   int        `json:"progress"` // 0-100
+	Message    string     `json:"message"`  // Latest progress line or final error
+	Log        string     `json:"log,omitempty" gorm:"type:text"`
+	Result     string     `json:"result,omitempty" gorm:"type:text"` // JSON summary set by the task
+	StartedAt  *time.Time `json:"started_at"`
+	FinishedAt *time.Time `json:"finished_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// JobLock is a lease allowing one running job per type across every process
+// sharing the database (the server's scheduler and the cmd/* tools). The
+// holder renews ExpiresAt while it runs; an expired lease is free to take.
+type JobLock struct {
+	Type      string    `json:"type" gorm:"primaryKey"`
+	Owner     string    `json:"owner"` // Random per attempt, so only the holder can renew or release
+	JobID     uint      `json:"job_id"`
+	ExpiresAt time.Time `json:"expires_at" gorm:"index"`
+}
+
+func MigrateJobs(db *gorm.DB) error {
+	return db.AutoMigrate(&Job{}, &JobLock{})
+}
+
+// AcquireJobLock takes the lease for jobType if it is free or expired
+func AcquireJobLock(db *gorm.DB, jobType, owner string, ttl time.Duration) (bool, error) {
+	now := time.Now()
+	res := db.Model(&JobLock{}).Where("type = ? AND expires_at < ?", jobType, now).
+		Updates(map[string]interface{}{"owner": owner, "job_id": 0, "expires_at": now.Add(ttl)})
+	if res.Error != nil {
+		return false, res.Error
+	}
+	if res.RowsAffected == 1 {
+		return true, nil
+	}
+	// First run of this type: nothing to update yet
+	res = db.Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&JobLock{Type: jobType, Owner: owner, ExpiresAt: now.Add(ttl)})
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected == 1, nil
+}
+
+// RenewJobLock extends a held lease and records the job holding it.
+// It returns false when the lease was lost (expired and taken by another process).
+func RenewJobLock(db *gorm.DB, jobType, owner string, jobID uint, ttl time.Duration) bool {
+	res := db.Model(&JobLock{}).Where("type = ? AND owner = ?", jobType, owner).
+		Updates(map[string]interface{}{"job_id": jobID, "expires_at": time.Now().Add(ttl)})
+	return res.Error == nil && res.RowsAffected == 1
+}
+
+// ReleaseJobLock frees a held lease
+func ReleaseJobLock(db *gorm.DB, jobType, owner string) {
+	db.Model(&JobLock{}).Where("type = ? AND owner = ?", jobType, owner).
+		Update("expires_at", time.Unix(0, 0))
+}
+
+// LiveJobLocks returns unexpired leases by job type
+func LiveJobLocks(db *gorm.DB) map[string]JobLock {
+	var locks []JobLock
+	db.Where("expires_at > ?", time.Now()).Find(&locks)
+	out := make(map[string]JobLock, len(locks))
+	for _, l := range locks {
+		out[l.Type] = l
+	}
+	return out
+}
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// SkipMarker stores intro/outro timestamps (in seconds) for the player.
+// A nil EpisodeIndex applies the marker to every episode of the drama.
+// EpisodeIndex is the provider's 0-based chapter index (StreamData.Chapter.Index),
+// so episode N of the 1-based /watch/{id}/{N} URLs is stored as N-1.
+type SkipMarker struct {
+	ID           uint      `json:"id" gorm:"primaryKey"`
+	BookID       string    `json:"bookId" gorm:"index;not null"`
+	EpisodeIndex *int      `json:"episodeIndex"` // 0-based chapter index, nil = whole drama
+	IntroStart   int       `json:"introStart"`
+	IntroEnd     int       `json:"introEnd"`
+	OutroStart   int       `json:"outroStart"`
+	OutroEnd     int       `json:"outroEnd"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+}
+
+func MigrateMarkers(db *gorm.DB) error {
+	return db.AutoMigrate(&SkipMarker{})
+}
+
+// FindSkipMarker returns the episode-specific marker, falling back to the drama-wide one
+func FindSkipMarker(db *gorm.DB, bookID string, episodeIndex int) *SkipMarker {
+	var marker SkipMarker
+	if err := db.Where("book_id = ? AND episode_index = ?", bookID, episodeIndex).First(&marker).Error; err == nil {
+		return &marker
+	}
+	if err := db.Where("book_id = ? AND episode_index IS NULL", bookID).First(&marker).Error; err == nil {
+		return &marker
+	}
+	return nil
+}
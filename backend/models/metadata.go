@@ -0,0 +1,96 @@
+package models
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Drama.SeriesStatus values
+const (
+	SeriesOngoing   = "ongoing"
+	SeriesCompleted = "completed"
+)
+
+// countSuffixes maps shorthand multipliers, including Indonesian "rb" (ribu) and "jt" (juta)
+var countSuffixes = []struct {
+	suffix string
+	mult   float64
+}{
+	{"jt", 1e6}, {"rb", 1e3}, {"m", 1e6}, {"k", 1e3}, {"b", 1e9},
+}
+
+// ParseCount reads upstream likes/views such as "1234", "1,234", "12.5K", "1.2M" or "3 rb"
+func ParseCount(s string) int64 {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "" {
+		return 0
+	}
+
+	mult := 1.0
+	for _, cs := range countSuffixes {
+		if strings.HasSuffix(s, cs.suffix) {
+			mult = cs.mult
+			s = strings.TrimSpace(strings.TrimSuffix(s, cs.suffix))
+			break
+		}
+	}
+
+	if mult == 1 {
+		// Plain numbers use "," or "." as thousands separators
+		s = strings.NewReplacer(",", "", ".", "", " ", "").Replace(s)
+	} else {
+		// "1,5jt" uses a decimal comma
+		s = strings.ReplaceAll(s, ",", ".")
+	}
+
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil || f < 0 {
+		return 0
+	}
+	return int64(f * mult)
+}
+
+// SeriesStatusFor infers ongoing/completed from the advertised total and the
+// episodes actually published. Returns "" when the total is unknown.
+func SeriesStatusFor(total, published int) string {
+	if total <= 0 || published <= 0 {
+		return ""
+	}
+	if published >= total {
+		return SeriesCompleted
+	}
+	return SeriesOngoing
+}
+
+// releaseLayouts are the date formats upstreams use for release/shelf times
+var releaseLayouts = []string{
+	time.RFC3339,
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+// ParseReleaseDate reads an upstream release date: one of releaseLayouts or a
+// Unix timestamp in seconds or milliseconds. Returns nil when it can't be read.
+func ParseReleaseDate(s string) *time.Time {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil
+	}
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		if n <= 0 {
+			return nil
+		}
+		if n > 1e12 {
+			n /= 1000
+		}
+		t := time.Unix(n, 0).UTC()
+		return &t
+	}
+	for _, layout := range releaseLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return &t
+		}
+	}
+	return nil
+}
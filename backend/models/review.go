@@ -0,0 +1,57 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GenreReview.Status values
+const (
+	ReviewPending  = "pending"
+	ReviewApproved = "approved"
+	ReviewRejected = "rejected"
+)
+
+// GenreSuggestion is one scored label proposed by the classifier
+type GenreSuggestion struct {
+	Slug       string   `json:"slug"`
+	Name       string   `json:"name"`
+	Score      float64  `json:"score"`
+	Confidence float64  `json:"confidence"`
+	Matches    []string `json:"matches"` // e.g. "title:naga (+6)"
+}
+
+// GenreReview queues a drama the classifier wasn't confident about
+type GenreReview struct {
+	ID          uint              `json:"id" gorm:"primaryKey"`
+	BookID      string            `json:"bookId" gorm:"uniqueIndex;not null"`
+	Judul       string            `json:"judul"`
+	Suggestions []GenreSuggestion `json:"suggestions" gorm:"serializer:json"`
+	Status      string            `json:"status" gorm:"index"`
+	CreatedAt   time.Time         `json:"created_at"`
+	ResolvedAt  *time.Time        `json:"resolved_at,omitempty"`
+}
+
+func MigrateReviews(db *gorm.DB) error {
+	return db.AutoMigrate(&GenreReview{})
+}
+
+// QueueReview adds or refreshes a pending review. Reviews an admin already
+// resolved are left alone.
+func QueueReview(db *gorm.DB, bookID, judul string, suggestions []GenreSuggestion) error {
+	review := GenreReview{BookID: bookID, Judul: judul, Suggestions: suggestions, Status: ReviewPending}
+	return db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "book_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"judul", "suggestions"}),
+		Where:     clause.Where{Exprs: []clause.Expression{clause.Eq{Column: clause.Column{Table: "genre_reviews", Name: "status"}, Value: ReviewPending}}},
+	}).Create(&review).Error
+}
+
+// ReviewResolved reports whether an admin already approved or rejected a drama's review
+func ReviewResolved(db *gorm.DB, bookID string) bool {
+	var count int64
+	db.Model(&GenreReview{}).Where("book_id = ? AND status <> ?", bookID, ReviewPending).Count(&count)
+	return count > 0
+}
@@ -0,0 +1,154 @@
+package models
+
+import (
+	"dramabang/services/titles"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// maxSearchTerms caps how many words of a query reach the index
+const maxSearchTerms = 8
+
+// sqliteSearchSchema is an FTS5 table over title, description and genre names,
+// kept in sync with dramas by triggers. Genre is the comma-joined names that
+// RefreshGenreString maintains, so genre edits reindex too.
+var sqliteSearchSchema = []string{
+	`CREATE VIRTUAL TABLE IF NOT EXISTS drama_fts USING fts5(
+		book_id UNINDEXED, title, description, genres,
+		tokenize = 'unicode61 remove_diacritics 2'
+	)`,
+	`CREATE TRIGGER IF NOT EXISTS dramas_fts_insert AFTER INSERT ON dramas BEGIN
+		INSERT INTO drama_fts (book_id, title, description, genres)
+		VALUES (new.book_id, coalesce(new.judul, '') || ' ' || coalesce(new.canonical_title, ''), coalesce(new.deskripsi, ''), coalesce(new.genre, ''));
+	END`,
+	`CREATE TRIGGER IF NOT EXISTS dramas_fts_delete AFTER DELETE ON dramas BEGIN
+		DELETE FROM drama_fts WHERE book_id = old.book_id;
+	END`,
+	`CREATE TRIGGER IF NOT EXISTS dramas_fts_update AFTER UPDATE OF judul, canonical_title, deskripsi, genre ON dramas
+	WHEN old.judul IS NOT new.judul OR old.canonical_title IS NOT new.canonical_title
+		OR old.deskripsi IS NOT new.deskripsi OR old.genre IS NOT new.genre
+	BEGIN
+		DELETE FROM drama_fts WHERE book_id = old.book_id;
+		INSERT INTO drama_fts (book_id, title, description, genres)
+		VALUES (new.book_id, coalesce(new.judul, '') || ' ' || coalesce(new.canonical_title, ''), coalesce(new.deskripsi, ''), coalesce(new.genre, ''));
+	END`,
+}
+
+// postgresSearchSchema adds a weighted tsvector column (title A, genres B,
+// description C). The dramabang config uses the Indonesian snowball stemmer
+// when the server has it and plain lowercasing otherwise.
+var postgresSearchSchema = []string{
+	`DO $$ BEGIN
+		IF NOT EXISTS (SELECT 1 FROM pg_ts_config WHERE cfgname = 'dramabang') THEN
+			IF EXISTS (SELECT 1 FROM pg_ts_config WHERE cfgname = 'indonesian') THEN
+				CREATE TEXT SEARCH CONFIGURATION dramabang (COPY = pg_catalog.indonesian);
+			ELSE
+				CREATE TEXT SEARCH CONFIGURATION dramabang (COPY = pg_catalog.simple);
+			END IF;
+		END IF;
+	END $$`,
+	`ALTER TABLE dramas ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+		setweight(to_tsvector('dramabang', coalesce(judul, '') || ' ' || coalesce(canonical_title, '')), 'A') ||
+		setweight(to_tsvector('dramabang', coalesce(genre, '')), 'B') ||
+		setweight(to_tsvector('dramabang', coalesce(deskripsi, '')), 'C')
+	) STORED`,
+	`CREATE INDEX IF NOT EXISTS idx_dramas_search_vector ON dramas USING GIN (search_vector)`,
+}
+
+// MigrateSearch creates the local full-text index for the configured driver
+func MigrateSearch(db *gorm.DB) error {
+	if db.Dialector.Name() == "postgres" {
+		for _, stmt := range postgresSearchSchema {
+			if err := db.Exec(stmt).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	for _, stmt := range sqliteSearchSchema {
+		if err := db.Exec(stmt).Error; err != nil {
+			return err
+		}
+	}
+	// Index rows stored before the triggers existed
+	var indexed, total int64
+	db.Table("drama_fts").Count(&indexed)
+	db.Model(&Drama{}).Count(&total)
+	if indexed != total {
+		return RebuildSearchIndex(db)
+	}
+	return nil
+}
+
+// RebuildSearchIndex repopulates the SQLite index from dramas; Postgres keeps
+// its generated column current by itself
+func RebuildSearchIndex(db *gorm.DB) error {
+	if db.Dialector.Name() == "postgres" {
+		return nil
+	}
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM drama_fts").Error; err != nil {
+			return err
+		}
+		return tx.Exec(`INSERT INTO drama_fts (book_id, title, description, genres)
+			SELECT book_id, coalesce(judul, '') || ' ' || coalesce(canonical_title, ''), coalesce(deskripsi, ''), coalesce(genre, '')
+			FROM dramas`).Error
+	})
+}
+
+// searchTerms splits a query into index-safe words (letters and digits only)
+func searchTerms(query string) []string {
+	terms := strings.Fields(titles.Key(query))
+	if len(terms) > maxSearchTerms {
+		terms = terms[:maxSearchTerms]
+	}
+	return terms
+}
+
+// SearchDramas ranks available catalog dramas against a query. Every word must
+// match as a prefix so partially typed queries still hit.
+func SearchDramas(db *gorm.DB, query string, limit int) ([]Drama, error) {
+	terms := searchTerms(query)
+	if len(terms) == 0 {
+		return nil, nil
+	}
+
+	var dramas []Drama
+	available := "dramas.availability IS NULL OR dramas.availability <> ?"
+
+	if db.Dialector.Name() == "postgres" {
+		parts := make([]string, len(terms))
+		for i, t := range terms {
+			parts[i] = t + ":*"
+		}
+		tsquery := strings.Join(parts, " & ")
+		err := db.Model(&Drama{}).
+			Where("search_vector @@ to_tsquery('dramabang', ?)", tsquery).
+			Where(available, AvailabilityUnavailable).
+			Order(clause.OrderBy{Expression: clause.Expr{
+				SQL:                "ts_rank_cd(search_vector, to_tsquery('dramabang', ?)) DESC, likes_count DESC",
+				Vars:               []interface{}{tsquery},
+				WithoutParentheses: true,
+			}}).
+			Limit(limit).
+			Find(&dramas).Error
+		return dramas, err
+	}
+
+	parts := make([]string, len(terms))
+	for i, t := range terms {
+		parts[i] = `"` + t + `"*`
+	}
+	// bm25 weights follow the column order: book_id, title, description, genres
+	err := db.Table("drama_fts").Select("dramas.*").
+		Joins("JOIN dramas ON dramas.book_id = drama_fts.book_id").
+		Where("drama_fts MATCH ?", strings.Join(parts, " ")).
+		Where(available, AvailabilityUnavailable).
+		Order("bm25(drama_fts, 0, 10.0, 1.0, 4.0), dramas.likes_count DESC").
+		Limit(limit).
+		Find(&dramas).Error
+	return dramas, err
+}
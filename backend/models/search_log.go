@@ -0,0 +1,74 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// SearchLog records one /api/search call
+type SearchLog struct {
+	ID        uint           `json:"id" gorm:"primaryKey"`
+	Query     string         `json:"query" gorm:"index"` // Normalized with titles.Key
+	Raw       string         `json:"raw"`
+	Results   int            `json:"results"`                          // Returned to the user
+	Providers map[string]int `json:"providers" gorm:"serializer:json"` // Results per upstream plus "local"
+	Source    string         `json:"source"`                           // live, blended, local or fuzzy
+	Corrected string         `json:"corrected,omitempty"`              // Set when fuzzy matching rewrote the query
+	LatencyMs int64          `json:"latency_ms"`
+	Session   string         `json:"session" gorm:"index"` // Anonymized user or client, rotates daily
+	CreatedAt time.Time      `json:"created_at" gorm:"index"`
+}
+
+func MigrateSearchLogs(db *gorm.DB) error {
+	return db.AutoMigrate(&SearchLog{})
+}
+
+// QueryStat aggregates the logs of one normalized query
+type QueryStat struct {
+	Query      string  `json:"query"`
+	Searches   int64   `json:"searches"`
+	Sessions   int64   `json:"sessions"`
+	AvgResults float64 `json:"avg_results"`
+	AvgLatency float64 `json:"avg_latency_ms"`
+	ZeroRate   float64 `json:"zero_rate"` // Share of searches that returned nothing
+}
+
+// TopQueries ranks queries made since a time by distinct sessions, then searches.
+// zeroOnly keeps queries that never returned results; withResults keeps those that did.
+func TopQueries(db *gorm.DB, since time.Time, limit int, zeroOnly, withResults bool) []QueryStat {
+	query := db.Model(&SearchLog{}).
+		Select("query, count(*) as searches, count(distinct session) as sessions, "+
+			"avg(results) as avg_results, avg(latency_ms) as avg_latency, "+
+			"avg(case when results = 0 then 1.0 else 0.0 end) as zero_rate").
+		Where("created_at >= ? AND query <> ''", since).
+		Group("query")
+	if zeroOnly {
+		query = query.Having("max(results) = 0")
+	}
+	if withResults {
+		query = query.Having("max(results) > 0")
+	}
+
+	var stats []QueryStat
+	query.Order("sessions desc, searches desc, query").Limit(limit).Scan(&stats)
+	return stats
+}
+
+// QuerySessions counts distinct sessions per query in [from, to)
+func QuerySessions(db *gorm.DB, from, to time.Time) map[string]int64 {
+	var rows []struct {
+		Query    string
+		Sessions int64
+	}
+	db.Model(&SearchLog{}).
+		Select("query, count(distinct session) as sessions").
+		Where("created_at >= ? AND created_at < ? AND query <> ''", from, to).
+		Group("query").
+		Scan(&rows)
+	out := make(map[string]int64, len(rows))
+	for _, r := range rows {
+		out[r.Query] = r.Sessions
+	}
+	return out
+}
@@ -0,0 +1,40 @@
+package models
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"hash/fnv"
+	"strconv"
+
+	sqlite "github.com/glebarez/go-sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SQLite has no hash function, so ShuffleOrder registers one. Postgres uses md5.
+func init() {
+	sqlite.MustRegisterDeterministicScalarFunction("seeded_hash", 2, func(_ *sqlite.FunctionContext, args []driver.Value) (driver.Value, error) {
+		h := fnv.New64a()
+		fmt.Fprintf(h, "%v|%s", args[1], args[0])
+		// FNV barely moves the high bits for IDs that differ in one digit; mix them (splitmix64)
+		x := h.Sum64()
+		x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+		x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+		x ^= x >> 31
+		return int64(x >> 1), nil
+	})
+}
+
+// ShuffleOrder orders dramas pseudo-randomly by a hash of book_id and seed, so
+// the same seed pages through the same shuffle without loading every row
+func ShuffleOrder(db *gorm.DB, seed int64) clause.OrderBy {
+	expr := clause.Expr{SQL: "seeded_hash(book_id, ?)", Vars: []interface{}{seed}}
+	if db.Dialector.Name() == "postgres" {
+		expr = clause.Expr{SQL: "md5(book_id || ?)", Vars: []interface{}{strconv.FormatInt(seed, 10)}}
+	}
+	return clause.OrderBy{Expression: clause.Expr{
+		SQL:                "?, book_id",
+		Vars:               []interface{}{expr},
+		WithoutParentheses: true,
+	}}
+}
@@ -0,0 +1,90 @@
+package models
+
+import (
+	"strconv"
+	"time"
+	"unicode"
+
+	"gorm.io/gorm"
+)
+
+// DramaSnapshot is one point in a drama's metadata history, recorded by
+// ingest whenever the episode count, title or cover changes
+type DramaSnapshot struct {
+	ID           uint      `json:"id" gorm:"primaryKey"`
+	BookID       string    `json:"bookId" gorm:"index;not null"`
+	EpisodeCount int       `json:"episode_count"`
+	PrevCount    int       `json:"prev_count"`
+	TotalEpisode string    `json:"total_episode"`
+	Judul        string    `json:"judul"`
+	Cover        string    `json:"cover"`
+	CreatedAt    time.Time `json:"created_at" gorm:"index"`
+}
+
+func MigrateSnapshots(db *gorm.DB) error {
+	if err := db.AutoMigrate(&DramaSnapshot{}); err != nil {
+		return err
+	}
+	// Unknown-count snapshots from older versions made 0 -> N look like growth
+	return db.Where("episode_count = 0").Delete(&DramaSnapshot{}).Error
+}
+
+// ParseEpisodeCount reads the first number in an upstream episode count ("80", "80 Episode")
+func ParseEpisodeCount(s string) int {
+	start := -1
+	for i, r := range s {
+		if unicode.IsDigit(r) {
+			if start < 0 {
+				start = i
+			}
+		} else if start >= 0 {
+			n, _ := strconv.Atoi(s[start:i])
+			return n
+		}
+	}
+	if start < 0 {
+		return 0
+	}
+	n, _ := strconv.Atoi(s[start:])
+	return n
+}
+
+// RecordSnapshot stores a snapshot if fresh differs from the latest one and
+// returns the previous episode count when the count went up (0 otherwise).
+// count is the known episode count, falling back to fresh.TotalEpisode when 0.
+// Nothing is recorded while the count is unknown, so a listing without one
+// (e.g. after a failed detail fetch) can't later look like growth from 0.
+func RecordSnapshot(db *gorm.DB, fresh Drama, count int) (prev int, increased bool) {
+	if count == 0 {
+		count = ParseEpisodeCount(fresh.TotalEpisode)
+	}
+	if count == 0 {
+		return 0, false
+	}
+
+	// Rows with a 0 count predate the check above and are ignored
+	var last DramaSnapshot
+	hasLast := db.Where("book_id = ? AND episode_count > 0", fresh.BookID).Order("id desc").First(&last).Error == nil
+	if hasLast && last.EpisodeCount == count && last.Judul == fresh.Judul && last.Cover == fresh.Cover {
+		return 0, false
+	}
+
+	snap := DramaSnapshot{
+		BookID:       fresh.BookID,
+		EpisodeCount: count,
+		PrevCount:    last.EpisodeCount,
+		TotalEpisode: fresh.TotalEpisode,
+		Judul:        fresh.Judul,
+		Cover:        fresh.Cover,
+	}
+	if err := db.Create(&snap).Error; err != nil {
+		return 0, false
+	}
+
+	// The first snapshot of a drama is its baseline, not an update
+	if hasLast && count > last.EpisodeCount {
+		db.Model(&Drama{}).Where("book_id = ?", fresh.BookID).Update("episodes_updated_at", snap.CreatedAt)
+		return last.EpisodeCount, true
+	}
+	return 0, false
+}
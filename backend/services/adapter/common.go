@@ -1,3 +1,129 @@
 package adapter
 
-const SapimuToken = "0ebd6cfdd8054d2a90aa2851532645211aeaf189fa1aed62c53e5fd735af8649"
+import (
+	"dramabang/services/imageproxy"
+	"dramabang/services/outbound"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+)
+
+// TokenSource hands out upstream bearer tokens (see services/credentials)
+type TokenSource interface {
+	Next(provider string) (id, token string, err error)
+	Count(provider string) int
+	ReportFailure(id string, status int)
+	ReportSuccess(id string)
+}
+
+// Credentials is set at startup once the vault is loaded from the database
+var Credentials TokenSource
+
+// ErrNoCredentials means a provider had no usable token, which says nothing about the drama
+var ErrNoCredentials = errors.New("no usable credentials")
+
+// ErrNotFound is returned by GetDetail when the upstream answers but no longer has the drama
+var ErrNotFound = errors.New("drama not found upstream")
+
+// StatusError is a non-200 upstream response
+type StatusError struct {
+	Code int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("status: %d", e.Code)
+}
+
+// doAuthorized sends a request with a bearer token for providerID. On 401/403
+// the token is reported and the request is retried with the next token.
+func doAuthorized(client *http.Client, providerID string, newReq func() (*http.Request, error)) (*http.Response, error) {
+	if Credentials == nil {
+		return nil, fmt.Errorf("%w: credential store not initialised", ErrNoCredentials)
+	}
+
+	attempts := Credentials.Count(providerID)
+	if attempts == 0 {
+		attempts = 1 // Let Next surface the "no usable credentials" error
+	}
+
+	var lastErr error
+	for i := 0; i < attempts; i++ {
+		id, token, err := Credentials.Next(providerID)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrNoCredentials, err)
+		}
+
+		req, err := newReq()
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+
+		if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+			resp.Body.Close()
+			Credentials.ReportFailure(id, resp.StatusCode)
+			lastErr = &StatusError{Code: resp.StatusCode}
+			continue
+		}
+
+		Credentials.ReportSuccess(id)
+		return resp, nil
+	}
+	return nil, lastErr
+}
+
+// newHTTPClient builds a provider's client. Requests go through the outbound
+// router, which applies the provider's proxy (if any) and records metrics.
+func newHTTPClient(providerID string, timeout time.Duration) *http.Client {
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: outbound.Default.Transport(providerID),
+	}
+}
+
+var (
+	imageHosts     []string
+	imageHostsOnce sync.Once
+)
+
+// proxyImageURL rewrites an upstream cover to go through our /api/img proxy.
+// IMAGE_PROXY_URL overrides the base (e.g. an absolute URL for the mobile app).
+// Covers on hosts the proxy doesn't allow are returned unchanged, so a
+// provider moving to a new CDN still shows its covers.
+func proxyImageURL(originalURL string) string {
+	if originalURL == "" {
+		return ""
+	}
+	imageHostsOnce.Do(func() { imageHosts = imageproxy.AllowedHosts() })
+	if !imageproxy.HostAllowed(originalURL, imageHosts) {
+		return originalURL
+	}
+	base := os.Getenv("IMAGE_PROXY_URL")
+	if base == "" {
+		base = "/api/img"
+	}
+	return base + "?url=" + url.QueryEscape(originalURL)
+}
+
+// IsNotFound reports whether err means the drama is gone upstream: ErrNotFound
+// or a 404/410. Anything else (network, rate limits, auth, 5xx, bad JSON) says
+// nothing about the drama.
+func IsNotFound(err error) bool {
+	if errors.Is(err, ErrNotFound) {
+		return true
+	}
+	var status *StatusError
+	if errors.As(err, &status) {
+		return status.Code == http.StatusNotFound || status.Code == http.StatusGone
+	}
+	return false
+}
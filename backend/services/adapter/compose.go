@@ -0,0 +1,240 @@
+package adapter
+
+import (
+	"dramabang/models"
+	"fmt"
+	"strings"
+)
+
+// maxPinnedPosition bounds pinned slots to the first screens of a feed
+const maxPinnedPosition = 100
+
+// FeedCap limits how many items one provider may place in the first Within
+// positions of a feed page (Within 0 = the whole page)
+type FeedCap struct {
+	Provider string `json:"provider"`
+	Max      int    `json:"max"`
+	Within   int    `json:"within"`
+}
+
+// PinnedSlot places an admin-chosen drama at a fixed 1-based position on page 1
+type PinnedSlot struct {
+	Position int    `json:"position"`
+	BookID   string `json:"bookId"`
+}
+
+// FeedRules controls how a merged feed is composed from provider results.
+// Zero value reproduces the plain round-robin merge.
+type FeedRules struct {
+	Weights     map[string]int `json:"weights"`       // Relative share per provider, missing = 1, 0 = excluded
+	Caps        []FeedCap      `json:"caps"`          // Per-provider caps
+	MaxGenreRun int            `json:"max_genre_run"` // Max consecutive items sharing a genre, 0 = off
+	Pinned      []PinnedSlot   `json:"pinned"`
+}
+
+// FeedComposition holds the rules for each merged feed (stored in settings)
+type FeedComposition struct {
+	Trending FeedRules `json:"trending"`
+	Latest   FeedRules `json:"latest"`
+}
+
+// Validate rejects rules that reference unknown providers or bad numbers
+func (r FeedRules) Validate() error {
+	for id, w := range r.Weights {
+		if _, ok := providerCapabilities[id]; !ok {
+			return fmt.Errorf("weights: unknown provider %s", id)
+		}
+		if w < 0 {
+			return fmt.Errorf("weights: %s must not be negative", id)
+		}
+	}
+	for _, c := range r.Caps {
+		if _, ok := providerCapabilities[c.Provider]; !ok {
+			return fmt.Errorf("caps: unknown provider %s", c.Provider)
+		}
+		if c.Max < 0 || c.Within < 0 {
+			return fmt.Errorf("caps: %s must not be negative", c.Provider)
+		}
+	}
+	if r.MaxGenreRun < 0 {
+		return fmt.Errorf("max_genre_run must not be negative")
+	}
+	for _, p := range r.Pinned {
+		if p.Position < 1 || p.Position > maxPinnedPosition || p.BookID == "" {
+			return fmt.Errorf("pinned: position must be 1-%d and bookId set", maxPinnedPosition)
+		}
+	}
+	return nil
+}
+
+// rulesFor returns the active rules for a feed ("trending" or "latest")
+func (m *Manager) rulesFor(feed string) FeedRules {
+	m.settingsMu.RLock()
+	defer m.settingsMu.RUnlock()
+	if feed == "latest" {
+		return m.composition.Latest
+	}
+	return m.composition.Trending
+}
+
+// ApplyFeedComposition swaps in new composition rules
+func (m *Manager) ApplyFeedComposition(c FeedComposition) {
+	m.settingsMu.Lock()
+	m.composition = c
+	m.settingsMu.Unlock()
+
+	m.invalidateFeeds()
+}
+
+// FeedComposition returns the active composition rules
+func (m *Manager) FeedComposition() FeedComposition {
+	m.settingsMu.RLock()
+	defer m.settingsMu.RUnlock()
+	return m.composition
+}
+
+// PreviewFeed composes a feed with the given rules without caching the result
+func (m *Manager) PreviewFeed(feed, locale string, rules FeedRules) ([]models.Drama, error) {
+	if feed != "trending" && feed != "latest" {
+		return nil, fmt.Errorf("unknown feed: %s", feed)
+	}
+	if err := rules.Validate(); err != nil {
+		return nil, err
+	}
+	providers, results := m.fetchFeed(feed, locale, 1)
+	return m.compose(rules, locale, 1, providers, results), nil
+}
+
+// primaryGenre is the first listed genre, used for diversity checks
+func primaryGenre(d models.Drama) string {
+	g := strings.SplitN(d.Genre, ",", 2)[0]
+	return strings.ToLower(strings.TrimSpace(g))
+}
+
+// compose merges per-provider results using smooth weighted round-robin,
+// then applies caps, genre diversity and pinned slots
+func (m *Manager) compose(rules FeedRules, locale string, page int, providers []Provider, results [][]models.Drama) []models.Drama {
+	n := len(providers)
+	weights := make([]int, n)
+	for i, p := range providers {
+		w, ok := rules.Weights[p.GetID()]
+		if !ok {
+			w = 1
+		}
+		weights[i] = w
+	}
+
+	heads := make([]int, n)   // Next unused item per provider
+	current := make([]int, n) // Smooth WRR running score
+	placed := make([]int, n)  // Items placed per provider
+
+	capped := func(i, pos int) bool {
+		for _, c := range rules.Caps {
+			if c.Provider == providers[i].GetID() && (c.Within == 0 || pos < c.Within) && placed[i] >= c.Max {
+				return true
+			}
+		}
+		return false
+	}
+
+	genreRun := func(merged []models.Drama, genre string) int {
+		run := 0
+		for j := len(merged) - 1; j >= 0 && primaryGenre(merged[j]) == genre; j-- {
+			run++
+		}
+		return run
+	}
+
+	var merged []models.Drama
+	for {
+		pos := len(merged)
+
+		// Raise the WRR score of every provider that can still place an item
+		eligible := make([]bool, n)
+		total := 0
+		for i := 0; i < n; i++ {
+			if heads[i] < len(results[i]) && weights[i] > 0 && !capped(i, pos) {
+				eligible[i] = true
+				current[i] += weights[i]
+				total += weights[i]
+			}
+		}
+
+		best, fallback := -1, -1
+		for i := 0; i < n; i++ {
+			if !eligible[i] {
+				continue
+			}
+			if fallback == -1 || current[i] > current[fallback] {
+				fallback = i
+			}
+			genre := primaryGenre(results[i][heads[i]])
+			if rules.MaxGenreRun > 0 && genre != "" && genreRun(merged, genre) >= rules.MaxGenreRun {
+				continue
+			}
+			if best == -1 || current[i] > current[best] {
+				best = i
+			}
+		}
+		if best == -1 {
+			// Diversity can't be satisfied, prefer filling the feed over leaving gaps
+			best = fallback
+		}
+		if best == -1 {
+			break
+		}
+
+		merged = append(merged, results[best][heads[best]])
+		heads[best]++
+		placed[best]++
+		current[best] -= total
+	}
+
+	if page == 1 && len(rules.Pinned) > 0 {
+		merged = m.applyPinned(locale, merged, rules.Pinned)
+	}
+	return merged
+}
+
+// applyPinned inserts pinned dramas at their positions, removing duplicates
+func (m *Manager) applyPinned(locale string, merged []models.Drama, pinned []PinnedSlot) []models.Drama {
+	byPos := make(map[int]models.Drama)
+	skip := make(map[string]bool)
+	for _, p := range pinned {
+		drama, _, err := m.GetDetail(locale, p.BookID)
+		if err != nil || drama == nil {
+			fmt.Printf("Skipping pinned drama %s: %v\n", p.BookID, err)
+			continue
+		}
+		d := *drama
+		d.Episodes = nil
+		byPos[p.Position] = d
+		skip[d.BookID] = true
+	}
+
+	var rest []models.Drama
+	for _, d := range merged {
+		if !skip[d.BookID] {
+			rest = append(rest, d)
+		}
+	}
+
+	out := make([]models.Drama, 0, len(rest)+len(byPos))
+	for pos := 1; len(rest) > 0 || len(byPos) > 0; pos++ {
+		if d, ok := byPos[pos]; ok {
+			out = append(out, d)
+			delete(byPos, pos)
+			continue
+		}
+		if len(rest) == 0 {
+			// Feed ran out before a pinned position: append the remaining pins in order
+			if len(byPos) > 0 {
+				continue
+			}
+			break
+		}
+		out = append(out, rest[0])
+		rest = rest[1:]
+	}
+	return out
+}
@@ -7,7 +7,6 @@ import (
 	"io"
 	"net/http"
 	"strconv"
-	"strings"
 	"time"
 )
 
@@ -17,11 +16,15 @@ type DramaboxProvider struct {
 
 const DramaboxAPI = "https://dramabos.asia/api/dramabox"
 
+// dramaboxLangs maps our locale codes to Dramabox "lang" values
+var dramaboxLangs = map[string]string{
+	"id": "in",
+	"en": "en",
+}
+
 func NewDramaboxProvider() *DramaboxProvider {
 	return &DramaboxProvider{
-		client: &http.Client{
-			Timeout: 30 * time.Second,
-		},
+		client: newHTTPClient("dramabox", 30*time.Second),
 	}
 }
 
@@ -33,6 +36,29 @@ func (p *DramaboxProvider) IsCompatibleID(id string) bool {
 	return true
 }
 
+// Normalize fills the generic fields; views, release date and episode count
+// come from the book payload (see toDrama). Dramabox sends no likes.
+func (p *DramaboxProvider) Normalize(locale string, d *models.Drama) {
+	normalizeBase(d, languageFor(dramaboxLangs, locale))
+}
+
+// toDrama maps a Dramabox book from any listing or detail response
+func (p *DramaboxProvider) toDrama(b dbBook) models.Drama {
+	d := models.Drama{
+		BookID:       "dramabox:" + b.BookID,
+		Judul:        b.BookName,
+		Cover:        p.proxyImage(b.Cover),
+		Deskripsi:    b.Introduction,
+		EpisodeCount: b.ChapterCount,
+		ViewsCount:   models.ParseCount(b.PlayCount),
+		ReleasedAt:   models.ParseReleaseDate(b.ShelfTime),
+	}
+	if b.ChapterCount > 0 {
+		d.TotalEpisode = strconv.Itoa(b.ChapterCount)
+	}
+	return d
+}
+
 func (p *DramaboxProvider) fetch(url string) ([]byte, error) {
 	// Retry logic (3 times)
 	var lastErr error
@@ -58,7 +84,7 @@ func (p *DramaboxProvider) fetch(url string) ([]byte, error) {
 
 		if resp.StatusCode != 200 {
 			resp.Body.Close()
-			lastErr = fmt.Errorf("status %d", resp.StatusCode)
+			lastErr = &StatusError{Code: resp.StatusCode}
 			time.Sleep(time.Duration(i+1) * 1 * time.Second)
 			continue
 		}
@@ -77,11 +103,7 @@ func (p *DramaboxProvider) fetch(url string) ([]byte, error) {
 
 // Helper to proxy images
 func (p *DramaboxProvider) proxyImage(originalURL string) string {
-	if originalURL == "" {
-		return ""
-	}
-	// Use wsrv.nl for caching, resizing and CORS proxy
-	return "https://wsrv.nl/?url=" + strings.ReplaceAll(originalURL, "https://", "") + "&output=jpg"
+	return proxyImageURL(originalURL)
 }
 
 // --- Internal Models ---
@@ -100,18 +122,16 @@ type dbBook struct {
 	BookName     string `json:"bookName"`
 	Cover        string `json:"cover"`
 	Introduction string `json:"introduction"`
+	ChapterCount int    `json:"chapterCount"`
+	PlayCount    string `json:"playCount"` // Abbreviated, e.g. "12.3M"
+	ShelfTime    string `json:"shelfTime"` // Release date, "2006-01-02 15:04:05"
 }
 
 type dbSearchList struct {
 	List []dbBook `json:"searchResult"`
 }
 
-type dbDetailData struct {
-	BookID       string `json:"bookId"`
-	BookName     string `json:"bookName"`
-	Cover        string `json:"cover"`
-	Introduction string `json:"introduction"`
-}
+type dbDetailData = dbBook
 
 type dbChapterList struct {
 	ChapterList []dbChapter `json:"chapterList"`
@@ -130,9 +150,9 @@ type dbPlayerData struct {
 
 // --- Implementation ---
 
-func (p *DramaboxProvider) GetTrending() ([]models.Drama, error) {
+func (p *DramaboxProvider) GetTrending(locale string) ([]models.Drama, error) {
 	// Endpoint: /foryou/1
-	body, err := p.fetch(DramaboxAPI + "/foryou/1")
+	body, err := p.fetch(fmt.Sprintf("%s/foryou/1?lang=%s", DramaboxAPI, upstreamLang(dramaboxLangs, locale)))
 	if err != nil {
 		return nil, err
 	}
@@ -153,22 +173,17 @@ func (p *DramaboxProvider) GetTrending() ([]models.Drama, error) {
 
 	var dramas []models.Drama
 	for _, b := range data.List {
-		dramas = append(dramas, models.Drama{
-			BookID:    "dramabox:" + b.BookID,
-			Judul:     b.BookName,
-			Cover:     p.proxyImage(b.Cover),
-			Deskripsi: b.Introduction,
-		})
+		dramas = append(dramas, p.toDrama(b))
 	}
 	return dramas, nil
 }
 
-func (p *DramaboxProvider) GetLatest(page int) ([]models.Drama, error) {
+func (p *DramaboxProvider) GetLatest(locale string, page int) ([]models.Drama, error) {
 	// Endpoint: /new/{page}
 	if page < 1 {
 		page = 1
 	}
-	url := fmt.Sprintf("%s/new/%d", DramaboxAPI, page)
+	url := fmt.Sprintf("%s/new/%d?lang=%s", DramaboxAPI, page, upstreamLang(dramaboxLangs, locale))
 	body, err := p.fetch(url)
 	if err != nil {
 		return nil, err
@@ -186,19 +201,14 @@ func (p *DramaboxProvider) GetLatest(page int) ([]models.Drama, error) {
 
 	var dramas []models.Drama
 	for _, b := range data.List {
-		dramas = append(dramas, models.Drama{
-			BookID:    "dramabox:" + b.BookID,
-			Judul:     b.BookName,
-			Cover:     p.proxyImage(b.Cover),
-			Deskripsi: b.Introduction,
-		})
+		dramas = append(dramas, p.toDrama(b))
 	}
 	return dramas, nil
 }
 
-func (p *DramaboxProvider) Search(query string) ([]models.Drama, error) {
+func (p *DramaboxProvider) Search(locale, query string) ([]models.Drama, error) {
 	// Endpoint: /search/{query}/1
-	urlSearch := fmt.Sprintf("%s/search/%s/1", DramaboxAPI, query)
+	urlSearch := fmt.Sprintf("%s/search/%s/1?lang=%s", DramaboxAPI, query, upstreamLang(dramaboxLangs, locale))
 
 	body, err := p.fetch(urlSearch)
 	if err != nil {
@@ -224,19 +234,14 @@ func (p *DramaboxProvider) Search(query string) ([]models.Drama, error) {
 
 	var dramas []models.Drama
 	for _, b := range data.List {
-		dramas = append(dramas, models.Drama{
-			BookID:    "dramabox:" + b.BookID,
-			Judul:     b.BookName,
-			Cover:     p.proxyImage(b.Cover),
-			Deskripsi: b.Introduction,
-		})
+		dramas = append(dramas, p.toDrama(b))
 	}
 	return dramas, nil
 }
 
-func (p *DramaboxProvider) GetDetail(id string) (*models.Drama, []models.Episode, error) {
-	// Endpoint: /drama/{id}?lang=in
-	urlDetail := fmt.Sprintf("%s/drama/%s?lang=in", DramaboxAPI, id)
+func (p *DramaboxProvider) GetDetail(locale, id string) (*models.Drama, []models.Episode, error) {
+	// Endpoint: /drama/{id}?lang={lang}
+	urlDetail := fmt.Sprintf("%s/drama/%s?lang=%s", DramaboxAPI, id, upstreamLang(dramaboxLangs, locale))
 	body, err := p.fetch(urlDetail)
 	if err != nil {
 		return nil, nil, err
@@ -251,6 +256,9 @@ func (p *DramaboxProvider) GetDetail(id string) (*models.Drama, []models.Episode
 	if err := json.Unmarshal(resp.Data, &detail); err != nil {
 		return nil, nil, err
 	}
+	if detail.BookID == "" {
+		return nil, nil, ErrNotFound
+	}
 
 	// Fetch Chapters: /chapters/{id}
 	urlChapters := fmt.Sprintf("%s/chapters/%s", DramaboxAPI, id)
@@ -269,13 +277,9 @@ func (p *DramaboxProvider) GetDetail(id string) (*models.Drama, []models.Episode
 		return nil, nil, err
 	}
 
-	drama := models.Drama{
-		BookID:       "dramabox:" + detail.BookID,
-		Judul:        detail.BookName,
-		Cover:        p.proxyImage(detail.Cover),
-		Deskripsi:    detail.Introduction,
-		TotalEpisode: strconv.Itoa(len(chapData.ChapterList)),
-	}
+	drama := p.toDrama(detail)
+	drama.TotalEpisode = strconv.Itoa(len(chapData.ChapterList))
+	drama.SeriesStatus = models.SeriesStatusFor(detail.ChapterCount, len(chapData.ChapterList))
 
 	var episodes []models.Episode
 	for _, ch := range chapData.ChapterList {
@@ -289,11 +293,11 @@ func (p *DramaboxProvider) GetDetail(id string) (*models.Drama, []models.Episode
 	return &drama, episodes, nil
 }
 
-func (p *DramaboxProvider) GetStream(id, epIndex string) (*models.StreamData, error) {
-	// Endpoint: /watch/player?bookId={id}&index={index}&lang=in
+func (p *DramaboxProvider) GetStream(locale, id, epIndex string) (*models.StreamData, error) {
+	// Endpoint: /watch/player?bookId={id}&index={index}&lang={lang}
 	idx, _ := strconv.Atoi(epIndex)
 
-	urlPlay := fmt.Sprintf("%s/watch/player?bookId=%s&index=%d&lang=in", DramaboxAPI, id, idx)
+	urlPlay := fmt.Sprintf("%s/watch/player?bookId=%s&index=%d&lang=%s", DramaboxAPI, id, idx, upstreamLang(dramaboxLangs, locale))
 	body, err := p.fetch(urlPlay)
 	if err != nil {
 		return nil, err
@@ -6,7 +6,6 @@ import (
 	"fmt"
 	"io"
 	"net/http"
-	"net/url"
 	"strconv"
 	"time"
 )
@@ -19,9 +18,7 @@ const DramaDashAPI = "https://dramabos.asia/api/dramadash/api"
 
 func NewDramaDashProvider() *DramaDashProvider {
 	return &DramaDashProvider{
-		client: &http.Client{
-			Timeout: 30 * time.Second,
-		},
+		client: newHTTPClient("dramadash", 30*time.Second),
 	}
 }
 
@@ -33,6 +30,13 @@ func (p *DramaDashProvider) IsCompatibleID(id string) bool {
 	return true
 }
 
+// Normalize fills the generic fields. DramaDash sends only title, poster and
+// description, and the detail lists published episodes without a total, so
+// views, likes, release date and status are unknown.
+func (p *DramaDashProvider) Normalize(locale string, d *models.Drama) {
+	normalizeBase(d, DefaultLocale)
+}
+
 func (p *DramaDashProvider) fetch(targetURL string) ([]byte, error) {
 	req, err := http.NewRequest("GET", targetURL, nil)
 	if err != nil {
@@ -48,17 +52,14 @@ func (p *DramaDashProvider) fetch(targetURL string) ([]byte, error) {
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("status: %d", resp.StatusCode)
+		return nil, &StatusError{Code: resp.StatusCode}
 	}
 
 	return io.ReadAll(resp.Body)
 }
 
 func (p *DramaDashProvider) proxyImage(originalURL string) string {
-	if originalURL == "" {
-		return ""
-	}
-	return "https://wsrv.nl/?url=" + url.QueryEscape(originalURL) + "&output=jpg"
+	return proxyImageURL(originalURL)
 }
 
 // --- Models ---
@@ -117,7 +118,7 @@ type ddStreamData struct {
 
 // --- Implementation ---
 
-func (p *DramaDashProvider) GetTrending() ([]models.Drama, error) {
+func (p *DramaDashProvider) GetTrending(locale string) ([]models.Drama, error) {
 	// Endpoint: /api/home
 	url := DramaDashAPI + "/home"
 	body, err := p.fetch(url)
@@ -142,11 +143,11 @@ func (p *DramaDashProvider) GetTrending() ([]models.Drama, error) {
 	return dramas, nil
 }
 
-func (p *DramaDashProvider) GetLatest(page int) ([]models.Drama, error) {
-	return p.GetTrending()
+func (p *DramaDashProvider) GetLatest(locale string, page int) ([]models.Drama, error) {
+	return p.GetTrending(locale)
 }
 
-func (p *DramaDashProvider) Search(query string) ([]models.Drama, error) {
+func (p *DramaDashProvider) Search(locale, query string) ([]models.Drama, error) {
 	// /api/search/cinta
 	url := fmt.Sprintf("%s/search/%s", DramaDashAPI, query)
 
@@ -189,7 +190,7 @@ func (p *DramaDashProvider) Search(query string) ([]models.Drama, error) {
 	return dramas, nil
 }
 
-func (p *DramaDashProvider) GetDetail(id string) (*models.Drama, []models.Episode, error) {
+func (p *DramaDashProvider) GetDetail(locale, id string) (*models.Drama, []models.Episode, error) {
 	// /api/drama/{id}
 	url := fmt.Sprintf("%s/drama/%s", DramaDashAPI, id)
 	body, err := p.fetch(url)
@@ -202,6 +203,9 @@ func (p *DramaDashProvider) GetDetail(id string) (*models.Drama, []models.Episod
 		return nil, nil, err
 	}
 	d := resp.Data
+	if d.ID == 0 {
+		return nil, nil, ErrNotFound
+	}
 
 	drama := models.Drama{
 		BookID:       "dramadash:" + strconv.Itoa(d.ID),
@@ -224,7 +228,7 @@ func (p *DramaDashProvider) GetDetail(id string) (*models.Drama, []models.Episod
 	return &drama, episodes, nil
 }
 
-func (p *DramaDashProvider) GetStream(id, epIndex string) (*models.StreamData, error) {
+func (p *DramaDashProvider) GetStream(locale, id, epIndex string) (*models.StreamData, error) {
 	// /api/episode/{id}/{epNum}
 	idx, _ := strconv.Atoi(epIndex)
 	epNum := idx + 1
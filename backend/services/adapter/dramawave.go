@@ -17,11 +17,15 @@ type DramaWaveProvider struct {
 
 const DramaWaveAPI = "https://dramabos.asia/api/dramawave/api/v1"
 
+// dramaWaveLangs maps our locale codes to DramaWave "lang" values
+var dramaWaveLangs = map[string]string{
+	"id": "id",
+	"en": "en",
+}
+
 func NewDramaWaveProvider() *DramaWaveProvider {
 	return &DramaWaveProvider{
-		client: &http.Client{
-			Timeout: 30 * time.Second,
-		},
+		client: newHTTPClient("dramawave", 30*time.Second),
 	}
 }
 
@@ -33,6 +37,12 @@ func (p *DramaWaveProvider) IsCompatibleID(id string) bool {
 	return true
 }
 
+// Normalize fills the generic fields. DramaWave sends an episode total but no
+// views, likes or release date; status is set when the detail lists episodes.
+func (p *DramaWaveProvider) Normalize(locale string, d *models.Drama) {
+	normalizeBase(d, languageFor(dramaWaveLangs, locale))
+}
+
 func (p *DramaWaveProvider) fetch(targetURL string) ([]byte, error) {
 	req, err := http.NewRequest("GET", targetURL, nil)
 	if err != nil {
@@ -50,17 +60,14 @@ func (p *DramaWaveProvider) fetch(targetURL string) ([]byte, error) {
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("status: %d", resp.StatusCode)
+		return nil, &StatusError{Code: resp.StatusCode}
 	}
 
 	return io.ReadAll(resp.Body)
 }
 
 func (p *DramaWaveProvider) proxyImage(originalURL string) string {
-	if originalURL == "" {
-		return ""
-	}
-	return "https://wsrv.nl/?url=" + url.QueryEscape(originalURL) + "&output=jpg"
+	return proxyImageURL(originalURL)
 }
 
 // --- Models ---
@@ -116,9 +123,9 @@ type dwStreamResp struct {
 
 // --- Implementation ---
 
-func (p *DramaWaveProvider) GetTrending() ([]models.Drama, error) {
-	// /feed/popular?lang=id
-	url := DramaWaveAPI + "/feed/popular?lang=id"
+func (p *DramaWaveProvider) GetTrending(locale string) ([]models.Drama, error) {
+	// /feed/popular?lang={lang}
+	url := DramaWaveAPI + "/feed/popular?lang=" + upstreamLang(dramaWaveLangs, locale)
 	body, err := p.fetch(url)
 	if err != nil {
 		return nil, err
@@ -147,10 +154,10 @@ func (p *DramaWaveProvider) GetTrending() ([]models.Drama, error) {
 	return dramas, nil
 }
 
-func (p *DramaWaveProvider) GetLatest(page int) ([]models.Drama, error) {
-	// /feed/new?lang=id
+func (p *DramaWaveProvider) GetLatest(locale string, page int) ([]models.Drama, error) {
+	// /feed/new?lang={lang}
 	// Using Trending logic but different endpoint
-	url := DramaWaveAPI + "/feed/new?lang=id"
+	url := DramaWaveAPI + "/feed/new?lang=" + upstreamLang(dramaWaveLangs, locale)
 	body, err := p.fetch(url)
 	if err != nil {
 		return nil, err
@@ -176,9 +183,9 @@ func (p *DramaWaveProvider) GetLatest(page int) ([]models.Drama, error) {
 	return dramas, nil
 }
 
-func (p *DramaWaveProvider) Search(query string) ([]models.Drama, error) {
-	// /search?q={q}&lang=id&page=1
-	url := fmt.Sprintf("%s/search?q=%s&lang=id&page=1", DramaWaveAPI, url.QueryEscape(query))
+func (p *DramaWaveProvider) Search(locale, query string) ([]models.Drama, error) {
+	// /search?q={q}&lang={lang}&page=1
+	url := fmt.Sprintf("%s/search?q=%s&lang=%s&page=1", DramaWaveAPI, url.QueryEscape(query), upstreamLang(dramaWaveLangs, locale))
 	body, err := p.fetch(url)
 	if err != nil {
 		return nil, err
@@ -225,9 +232,9 @@ func (p *DramaWaveProvider) Search(query string) ([]models.Drama, error) {
 	return dramas, nil
 }
 
-func (p *DramaWaveProvider) GetDetail(id string) (*models.Drama, []models.Episode, error) {
-	// /dramas/{id}?lang=id
-	url := fmt.Sprintf("%s/dramas/%s?lang=id", DramaWaveAPI, id)
+func (p *DramaWaveProvider) GetDetail(locale, id string) (*models.Drama, []models.Episode, error) {
+	// /dramas/{id}?lang={lang}
+	url := fmt.Sprintf("%s/dramas/%s?lang=%s", DramaWaveAPI, id, upstreamLang(dramaWaveLangs, locale))
 	body, err := p.fetch(url)
 	if err != nil {
 		return nil, nil, err
@@ -240,6 +247,9 @@ func (p *DramaWaveProvider) GetDetail(id string) (*models.Drama, []models.Episod
 		return nil, nil, err
 	}
 	d := resp.Data
+	if d.Title == "" && d.EpisodeCount == 0 && len(d.Episodes) == 0 {
+		return nil, nil, ErrNotFound
+	}
 
 	// If ID is empty in struct, maybe field name mismatch (e.g. "key" vs "id")
 	// We use passed ID as backup
@@ -286,17 +296,18 @@ func (p *DramaWaveProvider) GetDetail(id string) (*models.Drama, []models.Episod
 				EpisodeLabel: fmt.Sprintf("Episode %d", ep.Index),
 			})
 		}
+		drama.SeriesStatus = models.SeriesStatusFor(d.EpisodeCount, len(d.Episodes))
 	}
 
 	return &drama, episodes, nil
 }
 
-func (p *DramaWaveProvider) GetStream(id, epIndex string) (*models.StreamData, error) {
-	// /dramas/{id}/play/{ep}?lang=id
+func (p *DramaWaveProvider) GetStream(locale, id, epIndex string) (*models.StreamData, error) {
+	// /dramas/{id}/play/{ep}?lang={lang}
 	idx, _ := strconv.Atoi(epIndex)
 	epNum := idx + 1 // 1-based API
 
-	url := fmt.Sprintf("%s/dramas/%s/play/%d?lang=id", DramaWaveAPI, id, epNum)
+	url := fmt.Sprintf("%s/dramas/%s/play/%d?lang=%s", DramaWaveAPI, id, epNum, upstreamLang(dramaWaveLangs, locale))
 	body, err := p.fetch(url)
 	if err != nil {
 		return nil, err
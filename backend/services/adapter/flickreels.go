@@ -17,11 +17,15 @@ type FlickReelsProvider struct {
 
 const FlickReelsAPI = "https://dramabos.asia/api/flick"
 
+// flickReelsLangs maps our locale codes to FlickReels numeric "lang" values.
+// Only Indonesian is known so far; other locales fall back to it.
+var flickReelsLangs = map[string]string{
+	"id": "6",
+}
+
 func NewFlickReelsProvider() *FlickReelsProvider {
 	return &FlickReelsProvider{
-		client: &http.Client{
-			Timeout: 30 * time.Second,
-		},
+		client: newHTTPClient("flickreels", 30*time.Second),
 	}
 }
 
@@ -33,6 +37,12 @@ func (p *FlickReelsProvider) IsCompatibleID(id string) bool {
 	return true
 }
 
+// Normalize fills the generic fields. FlickReels sends the episodes uploaded
+// so far ("upload_num") but no total, views, likes or release date.
+func (p *FlickReelsProvider) Normalize(locale string, d *models.Drama) {
+	normalizeBase(d, languageFor(flickReelsLangs, locale))
+}
+
 func (p *FlickReelsProvider) fetch(targetURL string) ([]byte, error) {
 	req, err := http.NewRequest("GET", targetURL, nil)
 	if err != nil {
@@ -49,20 +59,14 @@ func (p *FlickReelsProvider) fetch(targetURL string) ([]byte, error) {
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("status: %d", resp.StatusCode)
+		return nil, &StatusError{Code: resp.StatusCode}
 	}
 
 	return io.ReadAll(resp.Body)
 }
 
 func (p *FlickReelsProvider) proxyImage(originalURL string) string {
-	if originalURL == "" {
-		return ""
-	}
-	// FlickReels URLs might already be absolute or relative?
-	// Debug showed: https://zshipubcdn.farsunpteltd.com/playlet/1758184949_aS3nfwTBky.jpg
-	// Perfect for wsrv.nl
-	return "https://wsrv.nl/?url=" + url.QueryEscape(originalURL) + "&output=jpg"
+	return proxyImageURL(originalURL)
 }
 
 // --- Models ---
@@ -83,7 +87,7 @@ type frBook struct {
 	UploadNum string `json:"upload_num"` // "88" (string)
 }
 
-// Detail: /drama/{id}?lang=6
+// Detail: /drama/{id}?lang={lang} (6 = Indonesian)
 // Response structure assumed from previous attempts or guess.
 // If Home was weird, Detail might be too. But logic below likely used standard parsing.
 // Let's assume detail is {data: {...}}
@@ -112,8 +116,8 @@ type frEpisode struct {
 
 // --- Implementation ---
 
-func (p *FlickReelsProvider) GetTrending() ([]models.Drama, error) {
-	url := fmt.Sprintf("%s/home?page=1&page_size=20&lang=6", FlickReelsAPI)
+func (p *FlickReelsProvider) GetTrending(locale string) ([]models.Drama, error) {
+	url := fmt.Sprintf("%s/home?page=1&page_size=20&lang=%s", FlickReelsAPI, upstreamLang(flickReelsLangs, locale))
 	body, err := p.fetch(url)
 	if err != nil {
 		return nil, err
@@ -141,12 +145,12 @@ func (p *FlickReelsProvider) GetTrending() ([]models.Drama, error) {
 	return dramas, nil
 }
 
-func (p *FlickReelsProvider) GetLatest(page int) ([]models.Drama, error) {
-	return p.GetTrending()
+func (p *FlickReelsProvider) GetLatest(locale string, page int) ([]models.Drama, error) {
+	return p.GetTrending(locale)
 }
 
-func (p *FlickReelsProvider) Search(query string) ([]models.Drama, error) {
-	url := fmt.Sprintf("%s/search?keyword=%s&lang=6", FlickReelsAPI, url.QueryEscape(query))
+func (p *FlickReelsProvider) Search(locale, query string) ([]models.Drama, error) {
+	url := fmt.Sprintf("%s/search?keyword=%s&lang=%s", FlickReelsAPI, url.QueryEscape(query), upstreamLang(flickReelsLangs, locale))
 	body, err := p.fetch(url)
 	if err != nil {
 		return nil, err
@@ -177,8 +181,8 @@ func (p *FlickReelsProvider) Search(query string) ([]models.Drama, error) {
 	return dramas, nil
 }
 
-func (p *FlickReelsProvider) GetDetail(id string) (*models.Drama, []models.Episode, error) {
-	url := fmt.Sprintf("%s/drama/%s?lang=6", FlickReelsAPI, id)
+func (p *FlickReelsProvider) GetDetail(locale, id string) (*models.Drama, []models.Episode, error) {
+	url := fmt.Sprintf("%s/drama/%s?lang=%s", FlickReelsAPI, id, upstreamLang(flickReelsLangs, locale))
 	body, err := p.fetch(url)
 	if err != nil {
 		return nil, nil, err
@@ -191,23 +195,33 @@ func (p *FlickReelsProvider) GetDetail(id string) (*models.Drama, []models.Episo
 	}
 
 	// Manual extraction from map for robustness
-	data, ok := raw["data"].(map[string]interface{})
+	data, ok := raw["data"]
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid detail response")
+	}
+	if data == nil {
+		return nil, nil, ErrNotFound
+	}
+	dataMap, ok := data.(map[string]interface{})
 	if !ok {
 		return nil, nil, fmt.Errorf("invalid detail response")
 	}
 
-	title, _ := data["title"].(string)
-	cover, _ := data["cover"].(string)
-	intro, _ := data["introduce"].(string)
+	title, _ := dataMap["title"].(string)
+	cover, _ := dataMap["cover"].(string)
+	intro, _ := dataMap["introduce"].(string)
+	if title == "" && cover == "" {
+		return nil, nil, ErrNotFound
+	}
 
 	// Episodes ?
 	// Check for "chapter_list", "episode_list", "list"
 	var epList []interface{}
-	if val, ok := data["chapter_list"].([]interface{}); ok {
+	if val, ok := dataMap["chapter_list"].([]interface{}); ok {
 		epList = val
-	} else if val, ok := data["episode_list"].([]interface{}); ok {
+	} else if val, ok := dataMap["episode_list"].([]interface{}); ok {
 		epList = val
-	} else if val, ok := data["list"].([]interface{}); ok {
+	} else if val, ok := dataMap["list"].([]interface{}); ok {
 		epList = val
 	}
 
@@ -240,10 +254,10 @@ func (p *FlickReelsProvider) GetDetail(id string) (*models.Drama, []models.Episo
 	return &drama, episodes, nil
 }
 
-func (p *FlickReelsProvider) GetStream(id, epIndex string) (*models.StreamData, error) {
+func (p *FlickReelsProvider) GetStream(locale, id, epIndex string) (*models.StreamData, error) {
 	idx, _ := strconv.Atoi(epIndex)
 
-	url := fmt.Sprintf("%s/drama/%s?lang=6", FlickReelsAPI, id)
+	url := fmt.Sprintf("%s/drama/%s?lang=%s", FlickReelsAPI, id, upstreamLang(flickReelsLangs, locale))
 	body, err := p.fetch(url)
 	if err != nil {
 		return nil, err
@@ -6,15 +6,26 @@ import (
 	"fmt"
 	"io"
 	"net/http"
-	"net/url"
+	"strings"
+	"time"
 )
 
-type FreeShortProvider struct{}
+type FreeShortProvider struct {
+	client *http.Client
+}
 
 const FreeShortAPI = "https://sapimu.au/freeshort/api/v1"
 
+// freeShortLangs maps our locale codes to FreeShort "lang" values
+var freeShortLangs = map[string]string{
+	"id": "id-ID",
+	"en": "en-US",
+}
+
 func NewFreeShortProvider() *FreeShortProvider {
-	return &FreeShortProvider{}
+	return &FreeShortProvider{
+		client: newHTTPClient("freeshort", 30*time.Second),
+	}
 }
 
 func (p *FreeShortProvider) GetID() string {
@@ -25,36 +36,37 @@ func (p *FreeShortProvider) IsCompatibleID(id string) bool {
 	return true
 }
 
-func (p *FreeShortProvider) fetch(targetURL string) ([]byte, error) {
-	req, err := http.NewRequest("GET", targetURL, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Safari/537.36")
-	req.Header.Set("Accept", "application/json, text/plain, */*")
-	req.Header.Set("Authorization", "Bearer "+SapimuToken)
+// Normalize fills the generic fields. Only FreeShort's trending feed works and
+// it sends title, cover and tags, nothing else.
+func (p *FreeShortProvider) Normalize(locale string, d *models.Drama) {
+	normalizeBase(d, languageFor(freeShortLangs, locale))
+}
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+func (p *FreeShortProvider) fetch(targetURL string) ([]byte, error) {
+	// Bearer token comes from the credential vault (rotates on 401/403)
+	resp, err := doAuthorized(p.client, p.GetID(), func() (*http.Request, error) {
+		req, err := http.NewRequest("GET", targetURL, nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Safari/537.36")
+		req.Header.Set("Accept", "application/json, text/plain, */*")
+		return req, nil
+	})
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("status: %d", resp.StatusCode)
+		return nil, &StatusError{Code: resp.StatusCode}
 	}
 
 	return io.ReadAll(resp.Body)
 }
 
 func (p *FreeShortProvider) proxyImage(originalURL string) string {
-	if originalURL == "" {
-		return ""
-	}
-	// Use wsrv.nl for simple proxy/resize
-	return "https://wsrv.nl/?url=" + url.QueryEscape(originalURL) + "&output=jpg"
+	return proxyImageURL(originalURL)
 }
 
 // Reuse structs from Starshort if structure is identical (likely)
@@ -73,9 +85,9 @@ type fsItem struct {
 
 // fsDetail and fsEpisode reuse from before or generic
 
-func (p *FreeShortProvider) GetTrending() ([]models.Drama, error) {
+func (p *FreeShortProvider) GetTrending(locale string) ([]models.Drama, error) {
 	// Use /foryou for Trending
-	body, err := p.fetch(FreeShortAPI + "/foryou?lang=id-ID")
+	body, err := p.fetch(FreeShortAPI + "/foryou?lang=" + upstreamLang(freeShortLangs, locale))
 	if err != nil {
 		return nil, err
 	}
@@ -91,25 +103,26 @@ func (p *FreeShortProvider) GetTrending() ([]models.Drama, error) {
 			BookID: "freeshort:" + d.Key,
 			Judul:  d.Title,
 			Cover:  p.proxyImage(d.Cover),
-			Genre:  "", // d.Tags is []string
+			Genre:  strings.Join(d.Tags, ", "),
+			Tags:   d.Tags,
 		})
 	}
 	return dramas, nil
 }
 
-func (p *FreeShortProvider) GetLatest(page int) ([]models.Drama, error) {
+func (p *FreeShortProvider) GetLatest(locale string, page int) ([]models.Drama, error) {
 	// Use /foryou for Latest too (as we don't have explicit /new)
-	return p.GetTrending()
+	return p.GetTrending(locale)
 }
 
-func (p *FreeShortProvider) Search(query string) ([]models.Drama, error) {
+func (p *FreeShortProvider) Search(locale, query string) ([]models.Drama, error) {
 	return []models.Drama{}, nil
 }
 
-func (p *FreeShortProvider) GetDetail(id string) (*models.Drama, []models.Episode, error) {
+func (p *FreeShortProvider) GetDetail(locale, id string) (*models.Drama, []models.Episode, error) {
 	return nil, nil, fmt.Errorf("freeshort api unauthorized (401)")
 }
 
-func (p *FreeShortProvider) GetStream(id, epIndex string) (*models.StreamData, error) {
+func (p *FreeShortProvider) GetStream(locale, id, epIndex string) (*models.StreamData, error) {
 	return nil, fmt.Errorf("freeshort api unavailable (500)")
 }
@@ -0,0 +1,32 @@
+package adapter
+
+import (
+	"dramabang/models"
+	"strings"
+)
+
+// GetGenreFeed filters the cached trending feed down to items whose upstream
+// tags match a genre slug. It is not a provider genre feed: no upstream has a
+// tag endpoint we call, so only trending items from providers with the Tags
+// capability can appear.
+func (m *Manager) GetGenreFeed(locale, slug string) []models.Drama {
+	trending, err := m.GetTrending(locale)
+	if err != nil {
+		return nil
+	}
+
+	var out []models.Drama
+	for _, d := range trending {
+		prefix := strings.SplitN(d.BookID, ":", 2)[0]
+		if !providerCapabilities[prefix].Tags {
+			continue
+		}
+		for _, tag := range d.Tags {
+			if models.Slugify(tag) == slug {
+				out = append(out, d)
+				break
+			}
+		}
+	}
+	return out
+}
@@ -19,9 +19,7 @@ const HiShortAPI = "https://dramabos.asia/api/hishort/api/v1"
 
 func NewHiShortProvider() *HiShortProvider {
 	return &HiShortProvider{
-		client: &http.Client{
-			Timeout: 30 * time.Second,
-		},
+		client: newHTTPClient("hishort", 30*time.Second),
 	}
 }
 
@@ -33,6 +31,13 @@ func (p *HiShortProvider) IsCompatibleID(id string) bool {
 	return true
 }
 
+// Normalize fills the generic fields. HiShort sends only title, cover and
+// description, and its playlist has no total, so views, likes, release date
+// and status are unknown.
+func (p *HiShortProvider) Normalize(locale string, d *models.Drama) {
+	normalizeBase(d, DefaultLocale)
+}
+
 func (p *HiShortProvider) fetch(targetURL string) ([]byte, error) {
 	req, err := http.NewRequest("GET", targetURL, nil)
 	if err != nil {
@@ -49,17 +54,14 @@ func (p *HiShortProvider) fetch(targetURL string) ([]byte, error) {
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("status: %d", resp.StatusCode)
+		return nil, &StatusError{Code: resp.StatusCode}
 	}
 
 	return io.ReadAll(resp.Body)
 }
 
 func (p *HiShortProvider) proxyImage(originalURL string) string {
-	if originalURL == "" {
-		return ""
-	}
-	return "https://wsrv.nl/?url=" + url.QueryEscape(originalURL) + "&output=jpg"
+	return proxyImageURL(originalURL)
 }
 
 // --- Models ---
@@ -99,7 +101,7 @@ type hsEpisode struct {
 
 // --- Implementation ---
 
-func (p *HiShortProvider) GetTrending() ([]models.Drama, error) {
+func (p *HiShortProvider) GetTrending(locale string) ([]models.Drama, error) {
 	// /home?module=12&page=1
 	url := HiShortAPI + "/home?module=12&page=1"
 	body, err := p.fetch(url)
@@ -124,11 +126,11 @@ func (p *HiShortProvider) GetTrending() ([]models.Drama, error) {
 	return dramas, nil
 }
 
-func (p *HiShortProvider) GetLatest(page int) ([]models.Drama, error) {
-	return p.GetTrending()
+func (p *HiShortProvider) GetLatest(locale string, page int) ([]models.Drama, error) {
+	return p.GetTrending(locale)
 }
 
-func (p *HiShortProvider) Search(query string) ([]models.Drama, error) {
+func (p *HiShortProvider) Search(locale, query string) ([]models.Drama, error) {
 	// /search?q=love
 	url := fmt.Sprintf("%s/search?q=%s", HiShortAPI, url.QueryEscape(query))
 	body, err := p.fetch(url)
@@ -154,7 +156,7 @@ func (p *HiShortProvider) Search(query string) ([]models.Drama, error) {
 	return dramas, nil
 }
 
-func (p *HiShortProvider) GetDetail(id string) (*models.Drama, []models.Episode, error) {
+func (p *HiShortProvider) GetDetail(locale, id string) (*models.Drama, []models.Episode, error) {
 	// /video/{id} for detail
 	url := fmt.Sprintf("%s/video/%s", HiShortAPI, id)
 	body, err := p.fetch(url)
@@ -189,6 +191,9 @@ func (p *HiShortProvider) GetDetail(id string) (*models.Drama, []models.Episode,
 	if desc == "" {
 		desc, _ = dataMap["introduction"].(string)
 	}
+	if title == "" && cover == "" {
+		return nil, nil, ErrNotFound
+	}
 
 	drama := models.Drama{
 		BookID:    "hishort:" + id,
@@ -223,7 +228,7 @@ func (p *HiShortProvider) GetDetail(id string) (*models.Drama, []models.Episode,
 	return &drama, nil, nil
 }
 
-func (p *HiShortProvider) GetStream(id, epIndex string) (*models.StreamData, error) {
+func (p *HiShortProvider) GetStream(locale, id, epIndex string) (*models.StreamData, error) {
 	// /video/{id}?ep={ep}
 	idx, _ := strconv.Atoi(epIndex)
 	epNum := idx + 1
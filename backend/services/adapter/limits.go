@@ -0,0 +1,100 @@
+package adapter
+
+import (
+	"dramabang/services/ratelimit"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// How long a call may queue for a token before the provider is skipped
+const (
+	searchMaxWait   = 300 * time.Millisecond // Keystroke-driven, skip fast
+	feedMaxWait     = 2 * time.Second        // Trending / latest
+	detailMaxWait   = 5 * time.Second        // A user is waiting on this one drama
+	prefetchMaxWait = 0                      // Background, never queue
+)
+
+// DefaultProviderRule applies to every provider without a configured rule
+var DefaultProviderRule = ratelimit.Rule{Rate: 2, Burst: 5}
+
+// DefaultHostRules cap hosts shared by several adapters
+var DefaultHostRules = map[string]ratelimit.Rule{
+	"dramabos.asia": {Rate: 5, Burst: 10},
+	"sapimu.au":     {Rate: 3, Burst: 6},
+}
+
+// providerHosts maps provider IDs to the upstream host they call
+var providerHosts = map[string]string{
+	"dramabox":   hostOf(DramaboxAPI),
+	"melolo":     hostOf(MeloloAPI),
+	"netshort":   hostOf(NetshortAPI),
+	"starshort":  hostOf(StarshortAPI),
+	"freeshort":  hostOf(FreeShortAPI),
+	"shortmax":   hostOf(ShortMaxAPI),
+	"dramadash":  hostOf(DramaDashAPI),
+	"hishort":    hostOf(HiShortAPI),
+	"flickreels": hostOf(FlickReelsAPI),
+	"dramawave":  hostOf(DramaWaveAPI),
+}
+
+// ProviderHost returns the upstream host a provider calls ("" if unknown)
+func ProviderHost(providerID string) string {
+	return providerHosts[providerID]
+}
+
+func hostOf(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return u.Hostname()
+}
+
+// ProviderLimitKey and HostLimitKey name the limiter buckets
+func ProviderLimitKey(providerID string) string { return "provider:" + providerID }
+func HostLimitKey(host string) string           { return "host:" + host }
+
+// acquire waits for a token for the provider and its host
+func (m *Manager) acquire(providerID string, maxWait time.Duration) error {
+	keys := []string{ProviderLimitKey(providerID)}
+	if host := providerHosts[providerID]; host != "" {
+		keys = append(keys, HostLimitKey(host))
+	}
+	return m.limiter.Acquire(maxWait, keys...)
+}
+
+// ConfigureLimits applies rate rules keyed by bucket name ("provider:melolo",
+// "host:dramabos.asia"). Buckets missing from rules revert to their defaults.
+func (m *Manager) ConfigureLimits(rules map[string]ratelimit.Rule) {
+	for _, p := range m.providerList {
+		key := ProviderLimitKey(p.GetID())
+		if rule, ok := rules[key]; ok {
+			m.limiter.Configure(key, rule)
+		} else {
+			m.limiter.Configure(key, DefaultProviderRule)
+		}
+	}
+
+	for host, def := range DefaultHostRules {
+		key := HostLimitKey(host)
+		if rule, ok := rules[key]; ok {
+			m.limiter.Configure(key, rule)
+		} else {
+			m.limiter.Configure(key, def)
+		}
+	}
+
+	// Rules for hosts we don't have defaults for
+	for key, rule := range rules {
+		host, isHost := strings.CutPrefix(key, "host:")
+		if _, hasDefault := DefaultHostRules[host]; isHost && !hasDefault {
+			m.limiter.Configure(key, rule)
+		}
+	}
+}
+
+// RateLimitUsage reports today's usage of every bucket
+func (m *Manager) RateLimitUsage() []ratelimit.Usage {
+	return m.limiter.Usage()
+}
@@ -0,0 +1,32 @@
+package adapter
+
+import "strings"
+
+// DefaultLocale is the site locale used when a request doesn't specify one
+const DefaultLocale = "id"
+
+// SupportedLocales are the locale codes accepted by the /api endpoints
+var SupportedLocales = []string{"id", "en"}
+
+// NormalizeLocale maps user input ("en-US", "ID", "") to a supported locale code
+func NormalizeLocale(locale string) string {
+	locale = strings.ToLower(strings.TrimSpace(locale))
+	if i := strings.IndexAny(locale, "-_"); i > 0 {
+		locale = locale[:i]
+	}
+	for _, l := range SupportedLocales {
+		if l == locale {
+			return l
+		}
+	}
+	return DefaultLocale
+}
+
+// upstreamLang translates our locale into a provider's language code.
+// Providers without a mapping for the locale fall back to the default locale.
+func upstreamLang(langs map[string]string, locale string) string {
+	if code, ok := langs[locale]; ok {
+		return code
+	}
+	return langs[DefaultLocale]
+}
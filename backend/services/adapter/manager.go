@@ -2,18 +2,38 @@ package adapter
 
 import (
 	"dramabang/models"
+	"dramabang/services/ratelimit"
 	"fmt"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/patrickmn/go-cache"
+	"golang.org/x/sync/singleflight"
 )
 
 type Manager struct {
 	providers    map[string]Provider
 	providerList []Provider
 	cache        *cache.Cache
+
+	// prefetchSlots bounds background stream resolution per provider
+	prefetchSlots map[string]chan struct{}
+	// streams shares one upstream stream lookup between concurrent requests
+	// and prefetches for the same episode
+	streams singleflight.Group
+
+	// limiter throttles outbound calls per provider and per shared host
+	limiter *ratelimit.Limiter
+
+	// settings holds the admin enabled/priority switches per provider
+	settingsMu  sync.RWMutex
+	settings    map[string]ProviderSettings
+	composition FeedComposition
+
+	// errors keeps recent failed calls per provider for the admin console
+	errorsMu sync.Mutex
+	errors   map[string][]ProviderError
 }
 
 // Helper for caching detailed response
@@ -35,7 +55,14 @@ func NewManager() *Manager {
 	fr := NewFlickReelsProvider()
 	dw := NewDramaWaveProvider()
 
-	return &Manager{
+	providerList := []Provider{db, ml, ns, st, fs, sm, dd, hs, fr, dw}
+
+	prefetchSlots := make(map[string]chan struct{})
+	for _, p := range providerList {
+		prefetchSlots[p.GetID()] = make(chan struct{}, PrefetchSlotsPerProvider)
+	}
+
+	m := &Manager{
 		providers: map[string]Provider{
 			db.GetID(): db,
 			ml.GetID(): ml,
@@ -48,9 +75,25 @@ func NewManager() *Manager {
 			fr.GetID(): fr,
 			dw.GetID(): dw,
 		},
-		providerList: []Provider{db, ml, ns, st, fs, sm, dd, hs, fr, dw},
-		cache:        cache.New(30*time.Minute, 60*time.Minute),
+		providerList:  providerList,
+		cache:         cache.New(30*time.Minute, 60*time.Minute),
+		prefetchSlots: prefetchSlots,
+		limiter:       ratelimit.New(DefaultProviderRule),
+		settings:      defaultSettings(providerList),
+		errors:        make(map[string][]ProviderError),
+	}
+	m.ConfigureLimits(nil)
+
+	return m
+}
+
+// idLocale is cacheLocale for the provider owning fullID
+func (m *Manager) idLocale(fullID, locale string) string {
+	p, _, err := m.resolveProvider(fullID)
+	if err != nil {
+		return locale
 	}
+	return cacheLocale(p.GetID(), locale)
 }
 
 // resolveProvider parses "prefix:id" and returns the provider and raw ID
@@ -74,80 +117,130 @@ func (m *Manager) resolveProvider(fullID string) (Provider, string, error) {
 	return nil, "", fmt.Errorf("unknown provider: %s", prefix)
 }
 
-func (m *Manager) GetTrending() ([]models.Drama, error) {
+func (m *Manager) GetTrending(locale string) ([]models.Drama, error) {
 	// Check Cache
-	if x, found := m.cache.Get("trending"); found {
+	cacheKey := fmt.Sprintf("trending:%s", locale)
+	if x, found := m.cache.Get(cacheKey); found {
 		return x.([]models.Drama), nil
 	}
 
+	providers, results := m.fetchFeed("trending", locale, 1)
+	merged := m.compose(m.rulesFor("trending"), locale, 1, providers, results)
+
+	// Set Cache (30 mins) ONLY if not empty
+	if len(merged) > 0 {
+		m.cache.Set(cacheKey, merged, 30*time.Minute)
+	}
+
+	return merged, nil
+}
+
+// fetchFeed fans out a trending or latest call to every enabled provider.
+// Results are indexed like the returned providers; failed ones stay empty.
+func (m *Manager) fetchFeed(feed, locale string, page int) ([]Provider, [][]models.Drama) {
+	providers := m.activeProviders()
+
 	var wg sync.WaitGroup
-	results := make([][]models.Drama, len(m.providerList))
-	errors := make([]error, len(m.providerList))
+	results := make([][]models.Drama, len(providers))
 
-	for i, p := range m.providerList {
+	for i, p := range providers {
 		wg.Add(1)
 		go func(index int, prov Provider) {
 			defer wg.Done()
-			res, err := prov.GetTrending()
+			if err := m.acquire(prov.GetID(), feedMaxWait); err != nil {
+				fmt.Printf("Skipping %s from %s: %v\n", feed, prov.GetID(), err)
+				return
+			}
+			var res []models.Drama
+			var err error
+			if feed == "latest" {
+				res, err = prov.GetLatest(locale, page)
+			} else {
+				res, err = prov.GetTrending(locale)
+			}
 			if err != nil {
-				errors[index] = err
-				// Log error but continue?
-				fmt.Printf("Error fetching trending from %s: %v\n", prov.GetID(), err)
+				m.recordError(prov.GetID(), feed, err)
+				// Log error but continue
+				fmt.Printf("Error fetching %s from %s: %v\n", feed, prov.GetID(), err)
 				return
 			}
+			normalizeAll(prov, locale, res)
 			results[index] = res
 		}(i, p)
 	}
 	wg.Wait()
 
-	// Merge Round Robin
-	var merged []models.Drama
-	maxLen := 0
-	for _, res := range results {
-		if len(res) > maxLen {
-			maxLen = len(res)
-		}
-	}
+	return providers, results
+}
 
-	for i := 0; i < maxLen; i++ {
-		for _, res := range results {
-			if i < len(res) {
-				merged = append(merged, res[i])
-			}
-		}
-	}
+// SearchHealth reports how many search-capable providers answered a query
+type SearchHealth struct {
+	Queried  int            `json:"queried"`
+	Answered int            `json:"answered"`
+	Results  map[string]int `json:"results"` // Result count per provider that answered
+}
 
-	// Set Cache (30 mins) ONLY if not empty
-	if len(merged) > 0 {
-		m.cache.Set("trending", merged, 30*time.Minute)
+// Ratio is the share of queried providers that answered (1 when none were queried)
+func (h SearchHealth) Ratio() float64 {
+	if h.Queried == 0 {
+		return 1
 	}
+	return float64(h.Answered) / float64(h.Queried)
+}
 
-	return merged, nil
+type cachedSearch struct {
+	dramas []models.Drama
+	health SearchHealth
 }
 
-func (m *Manager) Search(query string) ([]models.Drama, error) {
+// Search queries every active search-capable provider and merges the results
+// round robin. Providers skipped by rate limits or failing count against health.
+func (m *Manager) Search(locale, query string) ([]models.Drama, SearchHealth, error) {
 	// Check Cache
-	cacheKey := fmt.Sprintf("search:%s", query)
+	cacheKey := fmt.Sprintf("search:%s:%s", locale, query)
 	if x, found := m.cache.Get(cacheKey); found {
-		return x.([]models.Drama), nil
+		cached := x.(cachedSearch)
+		return cached.dramas, cached.health, nil
+	}
+
+	var providers []Provider
+	for _, p := range m.activeProviders() {
+		if providerCapabilities[p.GetID()].Search {
+			providers = append(providers, p)
+		}
 	}
 
 	var wg sync.WaitGroup
-	results := make([][]models.Drama, len(m.providerList))
+	results := make([][]models.Drama, len(providers))
+	answered := make([]bool, len(providers))
 
-	for i, p := range m.providerList {
+	for i, p := range providers {
 		wg.Add(1)
 		go func(index int, prov Provider) {
 			defer wg.Done()
-			res, err := prov.Search(query)
+			if err := m.acquire(prov.GetID(), searchMaxWait); err != nil {
+				fmt.Printf("Skipping search on %s: %v\n", prov.GetID(), err)
+				return
+			}
+			res, err := prov.Search(locale, query)
 			if err != nil {
+				m.recordError(prov.GetID(), "search", err)
 				fmt.Printf("Error searching %s: %v\n", prov.GetID(), err)
 				return
 			}
+			normalizeAll(prov, locale, res)
 			results[index] = res
+			answered[index] = true
 		}(i, p)
 	}
 	wg.Wait()
+	health := SearchHealth{Queried: len(providers), Results: map[string]int{}}
+	for i, ok := range answered {
+		if ok {
+			health.Answered++
+			health.Results[providers[i].GetID()] = len(results[i])
+		}
+	}
 
 	// Merge Round Robin
 	var merged []models.Drama
@@ -168,53 +261,21 @@ func (m *Manager) Search(query string) ([]models.Drama, error) {
 
 	// Set Cache (10 mins)
 	if len(merged) > 0 {
-		m.cache.Set(cacheKey, merged, 10*time.Minute)
+		m.cache.Set(cacheKey, cachedSearch{dramas: merged, health: health}, 10*time.Minute)
 	}
 
-	return merged, nil
+	return merged, health, nil
 }
 
-func (m *Manager) GetLatest(page int) ([]models.Drama, error) {
+func (m *Manager) GetLatest(locale string, page int) ([]models.Drama, error) {
 	// Check Cache
-	cacheKey := fmt.Sprintf("latest:%d", page)
+	cacheKey := fmt.Sprintf("latest:%s:%d", locale, page)
 	if x, found := m.cache.Get(cacheKey); found {
 		return x.([]models.Drama), nil
 	}
 
-	var wg sync.WaitGroup
-	results := make([][]models.Drama, len(m.providerList))
-
-	for i, p := range m.providerList {
-		wg.Add(1)
-		go func(index int, prov Provider) {
-			defer wg.Done()
-			res, err := prov.GetLatest(page)
-			if err != nil {
-				// Log error but continue
-				fmt.Printf("Error fetching latest from %s: %v\n", prov.GetID(), err)
-				return
-			}
-			results[index] = res
-		}(i, p)
-	}
-	wg.Wait()
-
-	// Merge Round Robin
-	var merged []models.Drama
-	maxLen := 0
-	for _, res := range results {
-		if len(res) > maxLen {
-			maxLen = len(res)
-		}
-	}
-
-	for i := 0; i < maxLen; i++ {
-		for _, res := range results {
-			if i < len(res) {
-				merged = append(merged, res[i])
-			}
-		}
-	}
+	providers, results := m.fetchFeed("latest", locale, page)
+	merged := m.compose(m.rulesFor("latest"), locale, page, providers, results)
 
 	// Set Cache (15 mins)
 	if len(merged) > 0 {
@@ -224,9 +285,10 @@ func (m *Manager) GetLatest(page int) ([]models.Drama, error) {
 	return merged, nil
 }
 
-func (m *Manager) GetLatestFromProvider(providerID string, page int) ([]models.Drama, error) {
+func (m *Manager) GetLatestFromProvider(locale, providerID string, page int) ([]models.Drama, error) {
+	locale = cacheLocale(providerID, locale)
 	// Check Cache
-	cacheKey := fmt.Sprintf("latest:%s:%d", providerID, page)
+	cacheKey := fmt.Sprintf("latest:%s:%s:%d", locale, providerID, page)
 	if x, found := m.cache.Get(cacheKey); found {
 		return x.([]models.Drama), nil
 	}
@@ -235,11 +297,19 @@ func (m *Manager) GetLatestFromProvider(providerID string, page int) ([]models.D
 	if !ok {
 		return nil, fmt.Errorf("provider not found: %s", providerID)
 	}
+	if !m.isEnabled(providerID) {
+		return nil, fmt.Errorf("provider disabled: %s", providerID)
+	}
 
-	res, err := p.GetLatest(page)
+	if err := m.acquire(providerID, feedMaxWait); err != nil {
+		return nil, err
+	}
+	res, err := p.GetLatest(locale, page)
 	if err != nil {
+		m.recordError(providerID, "latest", err)
 		return nil, err
 	}
+	normalizeAll(p, locale, res)
 
 	// Set Cache (15 mins)
 	if len(res) > 0 {
@@ -249,29 +319,91 @@ func (m *Manager) GetLatestFromProvider(providerID string, page int) ([]models.D
 	return res, nil
 }
 
-func (m *Manager) GetDetail(fullID string) (*models.Drama, []models.Episode, error) {
+// FetchFeed calls one provider's trending or latest feed directly, bypassing
+// the cache. Background jobs use it and may queue longer for rate limits.
+func (m *Manager) FetchFeed(locale, providerID, feed string, page int) ([]models.Drama, error) {
+	p, ok := m.providers[providerID]
+	if !ok {
+		return nil, fmt.Errorf("provider not found: %s", providerID)
+	}
+	if err := m.acquire(providerID, detailMaxWait); err != nil {
+		return nil, err
+	}
+
+	var res []models.Drama
+	var err error
+	if feed == "latest" {
+		res, err = p.GetLatest(locale, page)
+	} else {
+		res, err = p.GetTrending(locale)
+	}
+	if err != nil {
+		m.recordError(providerID, feed, err)
+	}
+	normalizeAll(p, locale, res)
+	return res, err
+}
+
+func (m *Manager) GetDetail(locale, fullID string) (*models.Drama, []models.Episode, error) {
+	locale = m.idLocale(fullID, locale)
 	// Check Cache
-	cacheKey := fmt.Sprintf("detail:%s", fullID)
-	if x, found := m.cache.Get(cacheKey); found {
+	if x, found := m.cache.Get(fmt.Sprintf("detail:%s:%s", locale, fullID)); found {
 		cached := x.(CachedDetail)
 		return cached.Drama, cached.Episodes, nil
 	}
+	return m.FetchDetail(locale, fullID)
+}
+
+// FetchDetail always asks the provider, refreshing the cached detail. Ingest
+// uses it so a drama whose listing changed isn't stored from a stale cache.
+func (m *Manager) FetchDetail(locale, fullID string) (*models.Drama, []models.Episode, error) {
+	locale = m.idLocale(fullID, locale)
+	cacheKey := fmt.Sprintf("detail:%s:%s", locale, fullID)
 
 	p, rawID, err := m.resolveProvider(fullID)
 	if err != nil {
 		return nil, nil, err
 	}
-	drama, episodes, err := p.GetDetail(rawID)
+	if err := m.acquire(p.GetID(), detailMaxWait); err != nil {
+		return nil, nil, err
+	}
+	drama, episodes, err := p.GetDetail(locale, rawID)
+	if drama != nil {
+		p.Normalize(locale, drama)
+	}
 	if err == nil {
 		// Set Cache (60 mins)
 		m.cache.Set(cacheKey, CachedDetail{Drama: drama, Episodes: episodes}, 60*time.Minute)
+	} else {
+		m.recordError(p.GetID(), "detail", err)
 	}
 	return drama, episodes, err
 }
 
-func (m *Manager) GetStream(fullID, epIndex string) (*models.StreamData, error) {
+// CachedEpisodes returns a drama's episode list only if it is already cached
+func (m *Manager) CachedEpisodes(locale, fullID string) ([]models.Episode, bool) {
+	x, found := m.cache.Get(fmt.Sprintf("detail:%s:%s", m.idLocale(fullID, locale), fullID))
+	if !found {
+		return nil, false
+	}
+	return x.(CachedDetail).Episodes, true
+}
+
+func (m *Manager) GetStream(locale, fullID, epIndex string) (*models.StreamData, error) {
+	data, err := m.resolveStream(locale, fullID, epIndex, detailMaxWait)
+	if err == nil {
+		// Warm the next episode so binge-watching skips upstream latency
+		go m.prefetchNext(locale, fullID, epIndex)
+	}
+	return data, err
+}
+
+// resolveStream returns a cached stream or fetches it from the provider,
+// queueing up to maxWait for a rate limit token
+func (m *Manager) resolveStream(locale, fullID, epIndex string, maxWait time.Duration) (*models.StreamData, error) {
+	locale = m.idLocale(fullID, locale)
 	// Check Cache
-	cacheKey := fmt.Sprintf("stream:%s:%s", fullID, epIndex)
+	cacheKey := fmt.Sprintf("stream:%s:%s:%s", locale, fullID, epIndex)
 	if x, found := m.cache.Get(cacheKey); found {
 		return x.(*models.StreamData), nil
 	}
@@ -280,10 +412,37 @@ func (m *Manager) GetStream(fullID, epIndex string) (*models.StreamData, error)
 	if err != nil {
 		return nil, err
 	}
-	data, err := p.GetStream(rawID, epIndex)
+	return m.fetchStream(cacheKey, func() (*models.StreamData, error) {
+		if err := m.acquire(p.GetID(), maxWait); err != nil {
+			return nil, err
+		}
+		return m.streamFromProvider(p, cacheKey, locale, rawID, epIndex)
+	})
+}
+
+// fetchStream runs fetch once per cache key, handing its result to every
+// caller that asks for the same episode meanwhile
+func (m *Manager) fetchStream(cacheKey string, fetch func() (*models.StreamData, error)) (*models.StreamData, error) {
+	v, err, _ := m.streams.Do(cacheKey, func() (interface{}, error) {
+		if x, found := m.cache.Get(cacheKey); found {
+			return x.(*models.StreamData), nil
+		}
+		return fetch()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(*models.StreamData), nil
+}
+
+// streamFromProvider calls the upstream and caches a successful stream
+func (m *Manager) streamFromProvider(p Provider, cacheKey, locale, rawID, epIndex string) (*models.StreamData, error) {
+	data, err := p.GetStream(locale, rawID, epIndex)
 	if err == nil {
 		// Cache successful stream for 30 mins
 		m.cache.Set(cacheKey, data, 30*time.Minute)
+	} else {
+		m.recordError(p.GetID(), "stream", err)
 	}
 	return data, err
 }
@@ -9,14 +9,19 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"time"
 )
 
-type MeloloProvider struct{}
+type MeloloProvider struct {
+	client *http.Client
+}
 
 const MeloloAPI = "https://dramabos.asia/api/melolo/api/v1"
 
 func NewMeloloProvider() *MeloloProvider {
-	return &MeloloProvider{}
+	return &MeloloProvider{
+		client: newHTTPClient("melolo", 30*time.Second),
+	}
 }
 
 func (p *MeloloProvider) GetID() string {
@@ -27,6 +32,12 @@ func (p *MeloloProvider) IsCompatibleID(id string) bool {
 	return true // Relies on Manager routing
 }
 
+// Normalize fills the generic fields. Melolo sends an episode total but no
+// views, likes or release date; status is set from the detail's videos.
+func (p *MeloloProvider) Normalize(locale string, d *models.Drama) {
+	normalizeBase(d, DefaultLocale)
+}
+
 func (p *MeloloProvider) fetch(targetURL string) ([]byte, error) {
 	req, err := http.NewRequest("GET", targetURL, nil)
 	if err != nil {
@@ -47,26 +58,21 @@ func (p *MeloloProvider) fetch(targetURL string) ([]byte, error) {
 	req.Header.Set("Cache-Control", "no-cache")
 	req.Header.Set("Pragma", "no-cache")
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := p.client.Do(req)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("status: %d", resp.StatusCode)
+		return nil, &StatusError{Code: resp.StatusCode}
 	}
 
 	return io.ReadAll(resp.Body)
 }
 
 func (p *MeloloProvider) proxyImage(originalURL string) string {
-	if originalURL == "" {
-		return ""
-	}
-	// Proxy image through wsrv.nl for optimization and CORS
-	return "https://wsrv.nl/?url=" + url.QueryEscape(originalURL) + "&output=jpg"
+	return proxyImageURL(originalURL)
 }
 
 // --- Internal Models ---
@@ -126,7 +132,7 @@ type mStreamResponse struct {
 
 // --- Implementation ---
 
-func (p *MeloloProvider) GetTrending() ([]models.Drama, error) {
+func (p *MeloloProvider) GetTrending(locale string) ([]models.Drama, error) {
 	// Use /home for Trending
 	body, err := p.fetch(MeloloAPI + "/home?offset=0&count=20")
 	if err != nil {
@@ -152,12 +158,12 @@ func (p *MeloloProvider) GetTrending() ([]models.Drama, error) {
 	return dramas, nil
 }
 
-func (p *MeloloProvider) GetLatest(page int) ([]models.Drama, error) {
+func (p *MeloloProvider) GetLatest(locale string, page int) ([]models.Drama, error) {
 	// Reuse home for latest as well
-	return p.GetTrending()
+	return p.GetTrending(locale)
 }
 
-func (p *MeloloProvider) Search(query string) ([]models.Drama, error) {
+func (p *MeloloProvider) Search(locale, query string) ([]models.Drama, error) {
 	urlSearch := fmt.Sprintf("%s/search?q=%s&offset=0&count=20", MeloloAPI, url.QueryEscape(query))
 	body, err := p.fetch(urlSearch)
 	if err != nil {
@@ -184,7 +190,7 @@ func (p *MeloloProvider) Search(query string) ([]models.Drama, error) {
 	return dramas, nil
 }
 
-func (p *MeloloProvider) GetDetail(id string) (*models.Drama, []models.Episode, error) {
+func (p *MeloloProvider) GetDetail(locale, id string) (*models.Drama, []models.Episode, error) {
 	// Use /detail/{id}
 	urlDetail := fmt.Sprintf("%s/detail/%s", MeloloAPI, id)
 	body, err := p.fetch(urlDetail)
@@ -196,6 +202,9 @@ func (p *MeloloProvider) GetDetail(id string) (*models.Drama, []models.Episode,
 	if err := json.Unmarshal(body, &raw); err != nil {
 		return nil, nil, err
 	}
+	if raw.ID == "" {
+		return nil, nil, ErrNotFound
+	}
 
 	// Parse Drama Info (flat structure now)
 	drama := models.Drama{
@@ -215,11 +224,12 @@ func (p *MeloloProvider) GetDetail(id string) (*models.Drama, []models.Episode,
 			EpisodeLabel: fmt.Sprintf("Episode %d", vid.Episode),
 		})
 	}
+	drama.SeriesStatus = models.SeriesStatusFor(raw.Episodes, len(episodes))
 
 	return &drama, episodes, nil
 }
 
-func (p *MeloloProvider) GetStream(id, epIndex string) (*models.StreamData, error) {
+func (p *MeloloProvider) GetStream(locale, id, epIndex string) (*models.StreamData, error) {
 	// 1. Fetch Detail to map Index -> VID
 	urlDetail := fmt.Sprintf("%s/detail/%s", MeloloAPI, id)
 	bodyDetail, err := p.fetch(urlDetail)
@@ -26,6 +26,10 @@ func (p *MovieProvider) IsCompatibleID(id string) bool {
 	return strings.HasPrefix(id, "movie:")
 }
 
+func (p *MovieProvider) Normalize(locale string, d *models.Drama) {
+	normalizeBase(d, DefaultLocale)
+}
+
 // --- Python Exec Helper ---
 func (p *MovieProvider) runPython(args ...string) ([]byte, error) {
 	// args[0] = cmd (latest, detail, stream)
@@ -49,12 +53,12 @@ func (p *MovieProvider) runPython(args ...string) ([]byte, error) {
 
 // --- Implementation ---
 
-func (p *MovieProvider) GetTrending() ([]models.Drama, error) {
+func (p *MovieProvider) GetTrending(locale string) ([]models.Drama, error) {
 	// Alias to Latest page 1
-	return p.GetLatest(1)
+	return p.GetLatest(locale, 1)
 }
 
-func (p *MovieProvider) Search(query string) ([]models.Drama, error) {
+func (p *MovieProvider) Search(locale, query string) ([]models.Drama, error) {
 	// NOT SUPPORTED in Python Script yet?
 	// The provided scraper.py didn't have search.
 	// We can return empty for now or implement search in python later.
@@ -62,7 +66,7 @@ func (p *MovieProvider) Search(query string) ([]models.Drama, error) {
 	return []models.Drama{}, nil
 }
 
-func (p *MovieProvider) GetLatest(page int) ([]models.Drama, error) {
+func (p *MovieProvider) GetLatest(locale string, page int) ([]models.Drama, error) {
 	out, err := p.runPython("latest", "--page", fmt.Sprintf("%d", page))
 	if err != nil {
 		return nil, err
@@ -86,12 +90,13 @@ func (p *MovieProvider) GetLatest(page int) ([]models.Drama, error) {
 			Judul:  rm.Title,
 			Cover:  rm.Cover,
 			Genre:  rm.Genre,
+			Tags:   splitTags(rm.Genre),
 		})
 	}
 	return movies, nil
 }
 
-func (p *MovieProvider) GetDetail(id string) (*models.Drama, []models.Episode, error) {
+func (p *MovieProvider) GetDetail(locale, id string) (*models.Drama, []models.Episode, error) {
 	// ID is "movie:slug", python expects "slug"
 	rawID := strings.TrimPrefix(id, "movie:")
 	out, err := p.runPython("detail", "--url", rawID)
@@ -125,6 +130,7 @@ func (p *MovieProvider) GetDetail(id string) (*models.Drama, []models.Episode, e
 		Deskripsi:    res.Drama.Description,
 		Cover:        res.Drama.Cover,
 		Genre:        res.Drama.Genre,
+		Tags:         splitTags(res.Drama.Genre),
 		TotalEpisode: res.Drama.TotalEps,
 	}
 
@@ -140,7 +146,7 @@ func (p *MovieProvider) GetDetail(id string) (*models.Drama, []models.Episode, e
 	return drama, episodes, nil
 }
 
-func (p *MovieProvider) GetStream(id, epIndex string) (*models.StreamData, error) {
+func (p *MovieProvider) GetStream(locale, id, epIndex string) (*models.StreamData, error) {
 	rawID := strings.TrimPrefix(id, "movie:")
 	out, err := p.runPython("stream", "--url", rawID)
 	if err != nil {
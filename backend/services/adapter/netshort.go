@@ -9,6 +9,7 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"time"
 )
 
 type NetshortProvider struct {
@@ -17,11 +18,9 @@ type NetshortProvider struct {
 
 const NetshortAPI = "https://sapimu.au/netshort/api"
 
-// const SapimuToken moved to common.go
-
 func NewNetshortProvider() *NetshortProvider {
 	return &NetshortProvider{
-		client: &http.Client{},
+		client: newHTTPClient("netshort", 30*time.Second),
 	}
 }
 
@@ -33,23 +32,30 @@ func (p *NetshortProvider) IsCompatibleID(id string) bool {
 	return true
 }
 
-func (p *NetshortProvider) fetch(url string) ([]byte, error) {
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	req.Header.Set("User-Agent", "Dramabang/1.0")
-	req.Header.Set("Authorization", "Bearer "+SapimuToken)
+// Normalize fills the generic fields. Netshort listings carry only id, name
+// and cover and the detail adds the episode total, so there are no views,
+// likes or release date; status is set from the detail's episode list.
+func (p *NetshortProvider) Normalize(locale string, d *models.Drama) {
+	normalizeBase(d, DefaultLocale)
+}
 
-	resp, err := p.client.Do(req)
+func (p *NetshortProvider) fetch(url string) ([]byte, error) {
+	// Bearer token comes from the credential vault (rotates on 401/403)
+	resp, err := doAuthorized(p.client, p.GetID(), func() (*http.Request, error) {
+		req, err := http.NewRequest("GET", url, nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("User-Agent", "Dramabang/1.0")
+		return req, nil
+	})
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("status: %d", resp.StatusCode)
+		return nil, &StatusError{Code: resp.StatusCode}
 	}
 
 	return io.ReadAll(resp.Body)
@@ -85,7 +91,7 @@ type nsEpisode struct {
 
 // --- Implementation ---
 
-func (p *NetshortProvider) GetTrending() ([]models.Drama, error) {
+func (p *NetshortProvider) GetTrending(locale string) ([]models.Drama, error) {
 	body, err := p.fetch(NetshortAPI + "/foryou")
 	if err != nil {
 		return nil, err
@@ -113,12 +119,12 @@ func (p *NetshortProvider) GetTrending() ([]models.Drama, error) {
 	return dramas, nil
 }
 
-func (p *NetshortProvider) GetLatest(page int) ([]models.Drama, error) {
+func (p *NetshortProvider) GetLatest(locale string, page int) ([]models.Drama, error) {
 	// Fallback to Trending for now as we don't have a clear "Latest" endpoint with paging
-	return p.GetTrending()
+	return p.GetTrending(locale)
 }
 
-func (p *NetshortProvider) Search(query string) ([]models.Drama, error) {
+func (p *NetshortProvider) Search(locale, query string) ([]models.Drama, error) {
 	// Netshort prefers %20 over +
 	encodedQuery := url.QueryEscape(query)
 	encodedQuery = strings.ReplaceAll(encodedQuery, "+", "%20")
@@ -144,7 +150,7 @@ func (p *NetshortProvider) Search(query string) ([]models.Drama, error) {
 	return dramas, nil
 }
 
-func (p *NetshortProvider) GetDetail(id string) (*models.Drama, []models.Episode, error) {
+func (p *NetshortProvider) GetDetail(locale, id string) (*models.Drama, []models.Episode, error) {
 	url := fmt.Sprintf("%s/allepisode?shortPlayId=%s", NetshortAPI, id)
 	body, err := p.fetch(url)
 	if err != nil {
@@ -155,6 +161,9 @@ func (p *NetshortProvider) GetDetail(id string) (*models.Drama, []models.Episode
 	if err := json.Unmarshal(body, &raw); err != nil {
 		return nil, nil, err
 	}
+	if raw.ShortPlayId == "" {
+		return nil, nil, ErrNotFound
+	}
 
 	drama := models.Drama{
 		BookID:       "netshort:" + raw.ShortPlayId,
@@ -172,11 +181,12 @@ func (p *NetshortProvider) GetDetail(id string) (*models.Drama, []models.Episode
 			EpisodeLabel: fmt.Sprintf("Episode %d", ep.EpisodeNo),
 		})
 	}
+	drama.SeriesStatus = models.SeriesStatusFor(raw.TotalEpisode, len(episodes))
 
 	return &drama, episodes, nil
 }
 
-func (p *NetshortProvider) GetStream(id, epIndex string) (*models.StreamData, error) {
+func (p *NetshortProvider) GetStream(locale, id, epIndex string) (*models.StreamData, error) {
 	// Must fetch detail to get playVoucher
 	url := fmt.Sprintf("%s/allepisode?shortPlayId=%s", NetshortAPI, id)
 	body, err := p.fetch(url)
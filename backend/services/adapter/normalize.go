@@ -0,0 +1,66 @@
+package adapter
+
+import (
+	"dramabang/models"
+	"dramabang/services/titles"
+	"strings"
+)
+
+// normalizeBase fills the typed fields every adapter can derive from its
+// free-form strings. Values an adapter already set are kept.
+func normalizeBase(d *models.Drama, language string) {
+	if d.EpisodeCount == 0 {
+		d.EpisodeCount = models.ParseEpisodeCount(d.TotalEpisode)
+	}
+	if d.LikesCount == 0 {
+		d.LikesCount = models.ParseCount(d.Likes)
+	}
+	if d.Language == "" {
+		d.Language = language
+	}
+
+	info := titles.Parse(d.Judul)
+	d.CanonicalTitle = info.Canonical
+	d.TitleKey = info.Key
+	d.Dubbed = d.Dubbed || info.Dubbed
+	if d.AudioLanguage == "" {
+		d.AudioLanguage = info.AudioLanguage
+	}
+}
+
+// languageFor returns the locale a provider actually served: the requested
+// one if it has an upstream code for it, otherwise its default
+func languageFor(langs map[string]string, locale string) string {
+	if _, ok := langs[locale]; ok {
+		return locale
+	}
+	return DefaultLocale
+}
+
+// normalizeAll runs the provider's normalizer over a result list
+func normalizeAll(p Provider, locale string, dramas []models.Drama) {
+	for i := range dramas {
+		p.Normalize(locale, &dramas[i])
+	}
+}
+
+// NormalizeStored re-derives typed fields for a stored drama using its provider's
+// normalizer; rows with an unknown provider prefix get the generic rules
+func (m *Manager) NormalizeStored(d *models.Drama) {
+	if p, _, err := m.resolveProvider(d.BookID); err == nil {
+		p.Normalize(DefaultLocale, d)
+		return
+	}
+	normalizeBase(d, DefaultLocale)
+}
+
+// splitTags reads a comma-separated upstream genre string
+func splitTags(s string) []string {
+	var tags []string
+	for _, t := range strings.Split(s, ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			tags = append(tags, t)
+		}
+	}
+	return tags
+}
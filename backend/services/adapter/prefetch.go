@@ -0,0 +1,119 @@
+package adapter
+
+import (
+	"dramabang/models"
+	"fmt"
+	"strconv"
+	"sync"
+)
+
+// PrefetchSlotsPerProvider caps concurrent background stream lookups per upstream
+const PrefetchSlotsPerProvider = 2
+
+// MaxBatchEpisodes caps how many episodes a single batch call may resolve
+const MaxBatchEpisodes = 20
+
+// batchWorkers is the number of episodes resolved in parallel per batch call
+const batchWorkers = 3
+
+// prefetchNext resolves the episode after epIndex in the background and stores
+// it in cache. It only runs when the drama's episode list is cached, so it
+// knows where the last episode is, and never blocks: if the provider has no
+// free slot or rate limit token the prefetch is skipped.
+func (m *Manager) prefetchNext(locale, fullID, epIndex string) {
+	idx, err := strconv.Atoi(epIndex)
+	if err != nil {
+		return
+	}
+	episodes, ok := m.CachedEpisodes(locale, fullID)
+	if !ok {
+		return
+	}
+	last := -1
+	for _, ep := range episodes {
+		if ep.EpisodeIndex > last {
+			last = ep.EpisodeIndex
+		}
+	}
+	if idx+1 > last {
+		return
+	}
+	next := strconv.Itoa(idx + 1)
+	locale = m.idLocale(fullID, locale)
+
+	cacheKey := fmt.Sprintf("stream:%s:%s:%s", locale, fullID, next)
+	if _, found := m.cache.Get(cacheKey); found {
+		return
+	}
+
+	p, rawID, err := m.resolveProvider(fullID)
+	if err != nil {
+		return
+	}
+
+	slots, ok := m.prefetchSlots[p.GetID()]
+	if !ok {
+		return
+	}
+	select {
+	case slots <- struct{}{}:
+		defer func() { <-slots }()
+	default:
+		// Provider busy, don't add pressure to the upstream
+		return
+	}
+	if err := m.acquire(p.GetID(), prefetchMaxWait); err != nil {
+		return
+	}
+
+	// The token is taken up front so a user request joining this lookup
+	// never fails on the prefetch's zero wait
+	_, err = m.fetchStream(cacheKey, func() (*models.StreamData, error) {
+		return m.streamFromProvider(p, cacheKey, locale, rawID, next)
+	})
+	if err != nil {
+		fmt.Printf("Prefetch failed for %s ep %s: %v\n", fullID, next, err)
+	}
+}
+
+// GetStreamBatch resolves episodes from..to (inclusive) for one drama.
+// Each episode reports its own success or error.
+func (m *Manager) GetStreamBatch(locale, fullID string, from, to int) ([]models.StreamBatchItem, error) {
+	if _, _, err := m.resolveProvider(fullID); err != nil {
+		return nil, err
+	}
+	if from < 0 || to < from {
+		return nil, fmt.Errorf("invalid episode range")
+	}
+	if to-from+1 > MaxBatchEpisodes {
+		return nil, fmt.Errorf("range too large (max %d episodes)", MaxBatchEpisodes)
+	}
+
+	items := make([]models.StreamBatchItem, to-from+1)
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < batchWorkers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				idx := from + i
+				data, err := m.resolveStream(locale, fullID, strconv.Itoa(idx), detailMaxWait)
+				if err != nil {
+					items[i] = models.StreamBatchItem{Index: idx, Status: "error", Error: err.Error()}
+					continue
+				}
+				items[i] = models.StreamBatchItem{Index: idx, Status: "success", Data: data}
+			}
+		}()
+	}
+
+	for i := range items {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return items, nil
+}
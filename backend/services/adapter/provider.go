@@ -4,13 +4,16 @@ import (
 	"dramabang/models"
 )
 
-// Provider maps a specific API source (e.g. Dramabox, Melolo)
+// Provider maps a specific API source (e.g. Dramabox, Melolo).
+// locale is one of SupportedLocales; each provider maps it to its own upstream code.
 type Provider interface {
 	GetID() string // "dramabox", "melolo", "netshort"
-	Search(query string) ([]models.Drama, error)
-	GetTrending() ([]models.Drama, error)
-	GetLatest(page int) ([]models.Drama, error)
-	GetDetail(id string) (*models.Drama, []models.Episode, error)
-	GetStream(id, epIndex string) (*models.StreamData, error)
+	Search(locale, query string) ([]models.Drama, error)
+	GetTrending(locale string) ([]models.Drama, error)
+	GetLatest(locale string, page int) ([]models.Drama, error)
+	GetDetail(locale, id string) (*models.Drama, []models.Episode, error)
+	GetStream(locale, id, epIndex string) (*models.StreamData, error)
 	IsCompatibleID(id string) bool
+	// Normalize fills typed metadata (counts, language, status) from upstream strings
+	Normalize(locale string, d *models.Drama)
 }
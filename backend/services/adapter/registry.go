@@ -0,0 +1,327 @@
+package adapter
+
+import (
+	"dramabang/services/outbound"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// recentErrorsPerProvider is how many failed calls are kept per provider
+const recentErrorsPerProvider = 20
+
+// Capabilities describes which Provider calls actually hit a real upstream endpoint
+type Capabilities struct {
+	Search       bool     `json:"search"`
+	Trending     bool     `json:"trending"`
+	Latest       bool     `json:"latest"`        // Has a dedicated "new" feed
+	LatestPaging bool     `json:"latest_paging"` // Latest honours the page argument
+	Detail       bool     `json:"detail"`
+	Stream       bool     `json:"stream"`
+	Tags         bool     `json:"tags"` // Listings carry upstream genre tags
+	Locales      []string `json:"locales"`
+}
+
+var providerCapabilities = map[string]Capabilities{
+	"dramabox":   {Search: true, Trending: true, Latest: true, LatestPaging: true, Detail: true, Stream: true, Locales: localesOf(dramaboxLangs)},
+	"melolo":     {Search: true, Trending: true, Detail: true, Stream: true, Locales: []string{DefaultLocale}},
+	"netshort":   {Search: true, Trending: true, Detail: true, Stream: true, Locales: []string{DefaultLocale}},
+	"starshort":  {Search: true, Trending: true, Detail: true, Stream: true, Tags: true, Locales: localesOf(starshortLangs)},
+	"freeshort":  {Trending: true, Tags: true, Locales: localesOf(freeShortLangs)},
+	"shortmax":   {Search: true, Trending: true, Detail: true, Stream: true, Tags: true, Locales: localesOf(shortMaxLangs)},
+	"dramadash":  {Search: true, Trending: true, Detail: true, Stream: true, Locales: []string{DefaultLocale}},
+	"hishort":    {Search: true, Trending: true, Detail: true, Stream: true, Locales: []string{DefaultLocale}},
+	"flickreels": {Search: true, Trending: true, Detail: true, Stream: true, Locales: localesOf(flickReelsLangs)},
+	"dramawave":  {Search: true, Trending: true, Latest: true, Detail: true, Stream: true, Locales: localesOf(dramaWaveLangs)},
+}
+
+// cacheLocale is the locale a provider's results are fetched and cached under.
+// Providers that ignore the locale share one entry rather than one per locale.
+func cacheLocale(providerID, locale string) string {
+	for _, l := range providerCapabilities[providerID].Locales {
+		if l == locale {
+			return locale
+		}
+	}
+	return DefaultLocale
+}
+
+func localesOf(langs map[string]string) []string {
+	var list []string
+	for l := range langs {
+		list = append(list, l)
+	}
+	sort.Strings(list)
+	return list
+}
+
+// ProviderSettings are the admin switches for one provider (stored in settings)
+type ProviderSettings struct {
+	Enabled  bool `json:"enabled"`
+	Priority int  `json:"priority"` // Position in the round-robin merge, lower goes first
+}
+
+// ProviderError is a failed provider call kept for the admin console
+type ProviderError struct {
+	At    time.Time `json:"at"`
+	Op    string    `json:"op"`
+	Error string    `json:"error"`
+}
+
+// ProviderInfo is the admin console view of a registered provider
+type ProviderInfo struct {
+	ID           string                   `json:"id"`
+	Host         string                   `json:"host"`
+	Capabilities Capabilities             `json:"capabilities"`
+	Enabled      bool                     `json:"enabled"`
+	Priority     int                      `json:"priority"`
+	CacheEntries int                      `json:"cache_entries"`
+	RecentErrors []ProviderError          `json:"recent_errors"`
+	Metrics      outbound.ProviderMetrics `json:"metrics"`
+}
+
+// defaultSettings enables every provider in registration order
+func defaultSettings(list []Provider) map[string]ProviderSettings {
+	settings := make(map[string]ProviderSettings, len(list))
+	for i, p := range list {
+		settings[p.GetID()] = ProviderSettings{Enabled: true, Priority: i}
+	}
+	return settings
+}
+
+// activeProviders returns enabled providers ordered by priority
+func (m *Manager) activeProviders() []Provider {
+	m.settingsMu.RLock()
+	defer m.settingsMu.RUnlock()
+
+	var list []Provider
+	for _, p := range m.providerList {
+		if m.settings[p.GetID()].Enabled {
+			list = append(list, p)
+		}
+	}
+	sort.SliceStable(list, func(i, j int) bool {
+		return m.settings[list[i].GetID()].Priority < m.settings[list[j].GetID()].Priority
+	})
+	return list
+}
+
+func (m *Manager) isEnabled(providerID string) bool {
+	m.settingsMu.RLock()
+	defer m.settingsMu.RUnlock()
+	return m.settings[providerID].Enabled
+}
+
+// ApplyProviderSettings merges stored settings over the defaults.
+// Unknown provider IDs are ignored.
+func (m *Manager) ApplyProviderSettings(stored map[string]ProviderSettings) {
+	next := defaultSettings(m.providerList)
+	for id, s := range stored {
+		if _, ok := next[id]; ok {
+			next[id] = s
+		}
+	}
+
+	m.settingsMu.Lock()
+	m.settings = next
+	m.settingsMu.Unlock()
+
+	m.invalidateFeeds()
+}
+
+// ProviderSettings returns the current switches for every provider
+func (m *Manager) ProviderSettings() map[string]ProviderSettings {
+	m.settingsMu.RLock()
+	defer m.settingsMu.RUnlock()
+
+	out := make(map[string]ProviderSettings, len(m.settings))
+	for id, s := range m.settings {
+		out[id] = s
+	}
+	return out
+}
+
+// ActiveProviderIDs returns enabled provider IDs in priority order
+func (m *Manager) ActiveProviderIDs() []string {
+	var ids []string
+	for _, p := range m.activeProviders() {
+		ids = append(ids, p.GetID())
+	}
+	return ids
+}
+
+// ProviderCapabilities returns what a provider's upstream supports
+func ProviderCapabilities(id string) Capabilities {
+	return providerCapabilities[id]
+}
+
+// HasDetail reports whether the drama's provider has a real detail endpoint
+func (m *Manager) HasDetail(fullID string) bool {
+	p, _, err := m.resolveProvider(fullID)
+	return err == nil && providerCapabilities[p.GetID()].Detail
+}
+
+// HasProvider reports whether id is a registered provider
+func (m *Manager) HasProvider(id string) bool {
+	_, ok := m.providers[id]
+	return ok
+}
+
+// invalidateFeeds drops merged results so provider changes show up immediately
+func (m *Manager) invalidateFeeds() {
+	for key := range m.cache.Items() {
+		if strings.HasPrefix(key, "trending:") || strings.HasPrefix(key, "latest:") || strings.HasPrefix(key, "search:") {
+			m.cache.Delete(key)
+		}
+	}
+}
+
+// InvalidateDrama drops cached detail for a drama in every locale
+func (m *Manager) InvalidateDrama(fullID string) {
+	for key := range m.cache.Items() {
+		if strings.HasPrefix(key, "detail:") && strings.HasSuffix(key, ":"+fullID) {
+			m.cache.Delete(key)
+		}
+	}
+}
+
+// recordError keeps the last few failures per provider
+func (m *Manager) recordError(providerID, op string, err error) {
+	m.errorsMu.Lock()
+	defer m.errorsMu.Unlock()
+
+	list := append(m.errors[providerID], ProviderError{At: time.Now(), Op: op, Error: err.Error()})
+	if len(list) > recentErrorsPerProvider {
+		list = list[len(list)-recentErrorsPerProvider:]
+	}
+	m.errors[providerID] = list
+}
+
+// Providers lists every registered provider for the admin console
+func (m *Manager) Providers() []ProviderInfo {
+	// Count cached detail/stream/latest entries per provider
+	cacheCounts := make(map[string]int)
+	for key := range m.cache.Items() {
+		for _, p := range m.providerList {
+			if strings.Contains(key, ":"+p.GetID()+":") {
+				cacheCounts[p.GetID()]++
+			}
+		}
+	}
+
+	settings := m.ProviderSettings()
+
+	m.errorsMu.Lock()
+	defer m.errorsMu.Unlock()
+
+	list := make([]ProviderInfo, 0, len(m.providerList))
+	for _, p := range m.providerList {
+		id := p.GetID()
+		errs := append([]ProviderError{}, m.errors[id]...)
+		list = append(list, ProviderInfo{
+			ID:           id,
+			Host:         providerHosts[id],
+			Capabilities: providerCapabilities[id],
+			Enabled:      settings[id].Enabled,
+			Priority:     settings[id].Priority,
+			CacheEntries: cacheCounts[id],
+			RecentErrors: errs,
+			Metrics:      outbound.Default.ProviderMetrics(id),
+		})
+	}
+	sort.SliceStable(list, func(i, j int) bool { return list[i].Priority < list[j].Priority })
+	return list
+}
+
+// RawResponse is one upstream response captured during a test call
+type RawResponse struct {
+	URL    string          `json:"url"`
+	Status int             `json:"status"`
+	JSON   json.RawMessage `json:"json,omitempty"`
+	Text   string          `json:"text,omitempty"` // Set when the body isn't JSON
+}
+
+// ProviderTestResult is the outcome of a live admin test call
+type ProviderTestResult struct {
+	Provider   string        `json:"provider"`
+	Op         string        `json:"op"`
+	DurationMs int64         `json:"duration_ms"`
+	Error      string        `json:"error,omitempty"`
+	Normalized interface{}   `json:"normalized"`
+	Raw        []RawResponse `json:"raw"`
+}
+
+// TestProvider runs one live call against a provider, bypassing cache, rate
+// limits and the enabled flag. arg is the query (search) or raw ID (detail, stream).
+func (m *Manager) TestProvider(providerID, op, locale, arg, epIndex string) (*ProviderTestResult, error) {
+	p, ok := m.providers[providerID]
+	if !ok {
+		return nil, fmt.Errorf("provider not found: %s", providerID)
+	}
+
+	var run func() (interface{}, error)
+	switch op {
+	case "trending":
+		run = func() (interface{}, error) {
+			res, err := p.GetTrending(locale)
+			normalizeAll(p, locale, res)
+			return res, err
+		}
+	case "latest":
+		run = func() (interface{}, error) {
+			res, err := p.GetLatest(locale, 1)
+			normalizeAll(p, locale, res)
+			return res, err
+		}
+	case "search":
+		run = func() (interface{}, error) {
+			res, err := p.Search(locale, arg)
+			normalizeAll(p, locale, res)
+			return res, err
+		}
+	case "detail":
+		run = func() (interface{}, error) {
+			drama, episodes, err := p.GetDetail(locale, arg)
+			if drama != nil {
+				p.Normalize(locale, drama)
+			}
+			return map[string]interface{}{"drama": drama, "episodes": episodes}, err
+		}
+	case "stream":
+		run = func() (interface{}, error) { return p.GetStream(locale, arg, epIndex) }
+	default:
+		return nil, fmt.Errorf("unknown op: %s", op)
+	}
+	if op != "trending" && op != "latest" && arg == "" {
+		return nil, fmt.Errorf("%s needs an argument", op)
+	}
+
+	result := &ProviderTestResult{Provider: providerID, Op: op}
+
+	var normalized interface{}
+	var callErr error
+	start := time.Now()
+	captured := outbound.Default.Capture(providerID, func() {
+		normalized, callErr = run()
+	})
+	result.DurationMs = time.Since(start).Milliseconds()
+	result.Normalized = normalized
+	if callErr != nil {
+		result.Error = callErr.Error()
+		m.recordError(providerID, "test:"+op, callErr)
+	}
+
+	for _, c := range captured {
+		raw := RawResponse{URL: c.URL, Status: c.Status}
+		if json.Valid(c.Body) {
+			raw.JSON = c.Body
+		} else {
+			raw.Text = string(c.Body)
+		}
+		result.Raw = append(result.Raw, raw)
+	}
+
+	return result, nil
+}
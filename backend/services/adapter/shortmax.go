@@ -18,11 +18,15 @@ type ShortMaxProvider struct {
 
 const ShortMaxAPI = "https://dramabos.asia/api/shortmax/api/v1"
 
+// shortMaxLangs maps our locale codes to ShortMax "lang" values
+var shortMaxLangs = map[string]string{
+	"id": "id",
+	"en": "en",
+}
+
 func NewShortMaxProvider() *ShortMaxProvider {
 	return &ShortMaxProvider{
-		client: &http.Client{
-			Timeout: 30 * time.Second,
-		},
+		client: newHTTPClient("shortmax", 30*time.Second),
 	}
 }
 
@@ -34,6 +38,12 @@ func (p *ShortMaxProvider) IsCompatibleID(id string) bool {
 	return true
 }
 
+// Normalize fills the generic fields. ShortMax sends favorites (stored as
+// likes), an episode total and tags, but no views or release date.
+func (p *ShortMaxProvider) Normalize(locale string, d *models.Drama) {
+	normalizeBase(d, languageFor(shortMaxLangs, locale))
+}
+
 func (p *ShortMaxProvider) fetch(targetURL string) ([]byte, error) {
 	req, err := http.NewRequest("GET", targetURL, nil)
 	if err != nil {
@@ -52,17 +62,14 @@ func (p *ShortMaxProvider) fetch(targetURL string) ([]byte, error) {
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("status: %d", resp.StatusCode)
+		return nil, &StatusError{Code: resp.StatusCode}
 	}
 
 	return io.ReadAll(resp.Body)
 }
 
 func (p *ShortMaxProvider) proxyImage(originalURL string) string {
-	if originalURL == "" {
-		return ""
-	}
-	return "https://wsrv.nl/?url=" + url.QueryEscape(originalURL) + "&output=jpg"
+	return proxyImageURL(originalURL)
 }
 
 // --- Internal Models ---
@@ -113,9 +120,9 @@ type smBatchMeta struct {
 
 // --- Implementation ---
 
-func (p *ShortMaxProvider) GetTrending() ([]models.Drama, error) {
-	// Use /home?lang=id
-	body, err := p.fetch(ShortMaxAPI + "/home?lang=id")
+func (p *ShortMaxProvider) GetTrending(locale string) ([]models.Drama, error) {
+	// Use /home?lang={lang}
+	body, err := p.fetch(ShortMaxAPI + "/home?lang=" + upstreamLang(shortMaxLangs, locale))
 	if err != nil {
 		return nil, err
 	}
@@ -135,21 +142,23 @@ func (p *ShortMaxProvider) GetTrending() ([]models.Drama, error) {
 			Deskripsi:    d.Summary,
 			TotalEpisode: strconv.Itoa(d.Episodes),
 			Likes:        strconv.Itoa(d.Favorites),
+			LikesCount:   int64(d.Favorites),
 			Genre:        strings.Join(d.Tags, ", "),
+			Tags:         d.Tags,
 		})
 	}
 	return dramas, nil
 }
 
-func (p *ShortMaxProvider) GetLatest(page int) ([]models.Drama, error) {
+func (p *ShortMaxProvider) GetLatest(locale string, page int) ([]models.Drama, error) {
 	// ShortMax doesn't seem to have specific pagination in provided endpoints
 	// Fallback to Trending/Home
-	return p.GetTrending()
+	return p.GetTrending(locale)
 }
 
-func (p *ShortMaxProvider) Search(query string) ([]models.Drama, error) {
-	// Endpoint: /search?q={q}&lang=id&page=1
-	urlSearch := fmt.Sprintf("%s/search?q=%s&lang=id&page=1", ShortMaxAPI, url.QueryEscape(query))
+func (p *ShortMaxProvider) Search(locale, query string) ([]models.Drama, error) {
+	// Endpoint: /search?q={q}&lang={lang}&page=1
+	urlSearch := fmt.Sprintf("%s/search?q=%s&lang=%s&page=1", ShortMaxAPI, url.QueryEscape(query), upstreamLang(shortMaxLangs, locale))
 	body, err := p.fetch(urlSearch)
 	if err != nil {
 		return nil, err
@@ -169,23 +178,26 @@ func (p *ShortMaxProvider) Search(query string) ([]models.Drama, error) {
 			Deskripsi:    d.Summary,
 			TotalEpisode: strconv.Itoa(d.Episodes),
 			Likes:        strconv.Itoa(d.Favorites),
+			LikesCount:   int64(d.Favorites),
 			Genre:        strings.Join(d.Tags, ", "),
+			Tags:         d.Tags,
 		})
 	}
 	return dramas, nil
 }
 
-func (p *ShortMaxProvider) GetDetail(id string) (*models.Drama, []models.Episode, error) {
+func (p *ShortMaxProvider) GetDetail(locale, id string) (*models.Drama, []models.Episode, error) {
 	// Strategy:
-	// 1. Fetch metadata from /batch/{id}?lang=id
+	// 1. Fetch metadata from /batch/{id}?lang={lang}
 	//    Usually this contains details.
 	//    If batch fails or is empty, we might only have episodes from /episodes.
 
-	urlBatch := fmt.Sprintf("%s/batch/%s?lang=id", ShortMaxAPI, id)
+	urlBatch := fmt.Sprintf("%s/batch/%s?lang=%s", ShortMaxAPI, id, upstreamLang(shortMaxLangs, locale))
 
 	dramaTitle := "ShortMax Drama " + id
 	dramaDesc := "No description available"
 	dramaCover := ""
+	var meta smItem // Batch metadata, for favorites and the episode total
 
 	// Fetch batch (optimistic)
 	if bodyBatch, err := p.fetch(urlBatch); err == nil {
@@ -214,6 +226,7 @@ func (p *ShortMaxProvider) GetDetail(id string) (*models.Drama, []models.Episode
 			dramaTitle = firstItem.Name
 			dramaDesc = firstItem.Summary
 			dramaCover = firstItem.Cover
+			meta = firstItem
 		} else {
 			// NDJSON fallback (common in ShortMax APIs)
 			lines := strings.Split(sBody, "\n")
@@ -223,13 +236,14 @@ func (p *ShortMaxProvider) GetDetail(id string) (*models.Drama, []models.Episode
 					dramaTitle = firstItem.Name
 					dramaDesc = firstItem.Summary
 					dramaCover = firstItem.Cover
+					meta = firstItem
 				}
 			}
 		}
 	}
 
-	// 2. Fetch Episodes List: /episodes/{id}?lang=id
-	urlEp := fmt.Sprintf("%s/episodes/%s?lang=id", ShortMaxAPI, id)
+	// 2. Fetch Episodes List: /episodes/{id}?lang={lang}
+	urlEp := fmt.Sprintf("%s/episodes/%s?lang=%s", ShortMaxAPI, id, upstreamLang(shortMaxLangs, locale))
 	bodyEp, err := p.fetch(urlEp)
 	if err != nil {
 		return nil, nil, err
@@ -239,6 +253,9 @@ func (p *ShortMaxProvider) GetDetail(id string) (*models.Drama, []models.Episode
 	if err := json.Unmarshal(bodyEp, &rawEp); err != nil {
 		return nil, nil, err
 	}
+	if len(rawEp.Data) == 0 {
+		return nil, nil, ErrNotFound
+	}
 
 	drama := models.Drama{
 		BookID:       "shortmax:" + id,
@@ -246,6 +263,11 @@ func (p *ShortMaxProvider) GetDetail(id string) (*models.Drama, []models.Episode
 		Cover:        p.proxyImage(dramaCover),
 		Deskripsi:    dramaDesc,
 		TotalEpisode: strconv.Itoa(len(rawEp.Data)),
+		SeriesStatus: models.SeriesStatusFor(meta.Episodes, len(rawEp.Data)),
+	}
+	if meta.Favorites > 0 {
+		drama.Likes = strconv.Itoa(meta.Favorites)
+		drama.LikesCount = int64(meta.Favorites)
 	}
 
 	var episodes []models.Episode
@@ -260,11 +282,11 @@ func (p *ShortMaxProvider) GetDetail(id string) (*models.Drama, []models.Episode
 	return &drama, episodes, nil
 }
 
-func (p *ShortMaxProvider) GetStream(id, epIndex string) (*models.StreamData, error) {
+func (p *ShortMaxProvider) GetStream(locale, id, epIndex string) (*models.StreamData, error) {
 	idx, _ := strconv.Atoi(epIndex)
 	epNum := idx + 1
 
-	urlPlay := fmt.Sprintf("%s/play/%s?lang=id&ep=%d", ShortMaxAPI, id, epNum)
+	urlPlay := fmt.Sprintf("%s/play/%s?lang=%s&ep=%d", ShortMaxAPI, id, upstreamLang(shortMaxLangs, locale), epNum)
 	body, err := p.fetch(urlPlay)
 	if err != nil {
 		return nil, err
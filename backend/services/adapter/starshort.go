@@ -9,14 +9,25 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"time"
 )
 
-type StarshortProvider struct{}
+type StarshortProvider struct {
+	client *http.Client
+}
 
 const StarshortAPI = "https://dramabos.asia/api/starshort/api/v1"
 
+// starshortLangs maps our locale codes to Starshort numeric "lang" values.
+// Only Indonesian is known so far; other locales fall back to it.
+var starshortLangs = map[string]string{
+	"id": "4",
+}
+
 func NewStarshortProvider() *StarshortProvider {
-	return &StarshortProvider{}
+	return &StarshortProvider{
+		client: newHTTPClient("starshort", 30*time.Second),
+	}
 }
 
 func (p *StarshortProvider) GetID() string {
@@ -27,6 +38,12 @@ func (p *StarshortProvider) IsCompatibleID(id string) bool {
 	return true
 }
 
+// Normalize fills the generic fields. Starshort sends views and tags but no
+// likes or release date; status is set from the detail's episode list.
+func (p *StarshortProvider) Normalize(locale string, d *models.Drama) {
+	normalizeBase(d, languageFor(starshortLangs, locale))
+}
+
 func (p *StarshortProvider) fetch(targetURL string) ([]byte, error) {
 	req, err := http.NewRequest("GET", targetURL, nil)
 	if err != nil {
@@ -40,25 +57,21 @@ func (p *StarshortProvider) fetch(targetURL string) ([]byte, error) {
 	req.Header.Set("Referer", "https://dramabos.asia/")
 	req.Header.Set("Origin", "https://dramabos.asia")
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := p.client.Do(req)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("status: %d", resp.StatusCode)
+		return nil, &StatusError{Code: resp.StatusCode}
 	}
 
 	return io.ReadAll(resp.Body)
 }
 
 func (p *StarshortProvider) proxyImage(originalURL string) string {
-	if originalURL == "" {
-		return ""
-	}
-	return "https://wsrv.nl/?url=" + url.QueryEscape(originalURL) + "&output=jpg"
+	return proxyImageURL(originalURL)
 }
 
 // --- Internal Models ---
@@ -95,6 +108,7 @@ type sDetailData struct {
 	Summary       string `json:"summary"`
 	Cover         string `json:"cover"`
 	TotalEpisodes int    `json:"episodes"`
+	Views         int    `json:"views"`
 	// API might differ slightly, inferred from 'episodes' in Home
 	Tags []string `json:"tags"`
 }
@@ -121,9 +135,9 @@ type sPlayResponse struct {
 
 // --- Implementation ---
 
-func (p *StarshortProvider) GetTrending() ([]models.Drama, error) {
-	// Use /home?lang=4 for Trending
-	body, err := p.fetch(StarshortAPI + "/home?lang=4")
+func (p *StarshortProvider) GetTrending(locale string) ([]models.Drama, error) {
+	// Use /home?lang={lang} for Trending
+	body, err := p.fetch(StarshortAPI + "/home?lang=" + upstreamLang(starshortLangs, locale))
 	if err != nil {
 		return nil, err
 	}
@@ -144,21 +158,23 @@ func (p *StarshortProvider) GetTrending() ([]models.Drama, error) {
 				Deskripsi:    d.Summary,
 				TotalEpisode: strconv.Itoa(d.Episodes),
 				Genre:        strings.Join(d.Tags, ", "),
+				Tags:         d.Tags,
+				ViewsCount:   int64(d.Views),
 			})
 		}
 	}
 	return dramas, nil
 }
 
-func (p *StarshortProvider) GetLatest(page int) ([]models.Drama, error) {
+func (p *StarshortProvider) GetLatest(locale string, page int) ([]models.Drama, error) {
 	// Use same as trending for now as no explicit latest pagination in user provided endpoints
 	// Or maybe one of the categories in Home is "New"?
-	return p.GetTrending()
+	return p.GetTrending(locale)
 }
 
-func (p *StarshortProvider) Search(query string) ([]models.Drama, error) {
-	// Endpoint: /search?q=cinta&lang=4
-	urlSearch := fmt.Sprintf("%s/search?q=%s&lang=4", StarshortAPI, url.QueryEscape(query))
+func (p *StarshortProvider) Search(locale, query string) ([]models.Drama, error) {
+	// Endpoint: /search?q=cinta&lang={lang}
+	urlSearch := fmt.Sprintf("%s/search?q=%s&lang=%s", StarshortAPI, url.QueryEscape(query), upstreamLang(starshortLangs, locale))
 	body, err := p.fetch(urlSearch)
 	if err != nil {
 		return nil, err
@@ -180,14 +196,16 @@ func (p *StarshortProvider) Search(query string) ([]models.Drama, error) {
 			Deskripsi:    d.Summary,
 			TotalEpisode: strconv.Itoa(d.Episodes),
 			Genre:        strings.Join(d.Tags, ", "),
+			Tags:         d.Tags,
+			ViewsCount:   int64(d.Views),
 		})
 	}
 	return dramas, nil
 }
 
-func (p *StarshortProvider) GetDetail(id string) (*models.Drama, []models.Episode, error) {
-	// 1. Fetch Detail Info: /drama/{id}?lang=4
-	urlDetail := fmt.Sprintf("%s/drama/%s?lang=4", StarshortAPI, id)
+func (p *StarshortProvider) GetDetail(locale, id string) (*models.Drama, []models.Episode, error) {
+	// 1. Fetch Detail Info: /drama/{id}?lang={lang}
+	urlDetail := fmt.Sprintf("%s/drama/%s?lang=%s", StarshortAPI, id, upstreamLang(starshortLangs, locale))
 	body, err := p.fetch(urlDetail)
 	if err != nil {
 		return nil, nil, err
@@ -198,6 +216,9 @@ func (p *StarshortProvider) GetDetail(id string) (*models.Drama, []models.Episod
 		return nil, nil, err
 	}
 	d := raw.Data
+	if d.ID == 0 {
+		return nil, nil, ErrNotFound
+	}
 
 	drama := models.Drama{
 		BookID:       "starshort:" + strconv.Itoa(d.ID),
@@ -206,10 +227,12 @@ func (p *StarshortProvider) GetDetail(id string) (*models.Drama, []models.Episod
 		Deskripsi:    d.Summary,
 		TotalEpisode: strconv.Itoa(d.TotalEpisodes),
 		Genre:        strings.Join(d.Tags, ", "),
+		Tags:         d.Tags,
+		ViewsCount:   int64(d.Views),
 	}
 
-	// 2. Fetch Episodes List: /episodes/{id}?lang=4
-	urlEp := fmt.Sprintf("%s/episodes/%s?lang=4", StarshortAPI, id)
+	// 2. Fetch Episodes List: /episodes/{id}?lang={lang}
+	urlEp := fmt.Sprintf("%s/episodes/%s?lang=%s", StarshortAPI, id, upstreamLang(starshortLangs, locale))
 	bodyEp, err := p.fetch(urlEp)
 	if err != nil {
 		return &drama, nil, err
@@ -231,16 +254,17 @@ func (p *StarshortProvider) GetDetail(id string) (*models.Drama, []models.Episod
 			EpisodeLabel: fmt.Sprintf("Episode %d", ep.Episode),
 		})
 	}
+	drama.SeriesStatus = models.SeriesStatusFor(d.TotalEpisodes, len(episodes))
 
 	return &drama, episodes, nil
 }
 
-func (p *StarshortProvider) GetStream(id, epIndex string) (*models.StreamData, error) {
-	// Endpoint: /play/{id}?ep={ep}&lang=4
+func (p *StarshortProvider) GetStream(locale, id, epIndex string) (*models.StreamData, error) {
+	// Endpoint: /play/{id}?ep={ep}&lang={lang}
 	idx, _ := strconv.Atoi(epIndex)
 	epNum := idx + 1 // API uses 1-based param based on user url example: ep=1
 
-	urlPlay := fmt.Sprintf("%s/play/%s?ep=%d&lang=4", StarshortAPI, id, epNum)
+	urlPlay := fmt.Sprintf("%s/play/%s?ep=%d&lang=%s", StarshortAPI, id, epNum, upstreamLang(starshortLangs, locale))
 	body, err := p.fetch(urlPlay)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,65 @@
+package classifier
+
+import (
+	"dramabang/models"
+
+	"gorm.io/gorm"
+)
+
+// Outcome of applying the classifier to a stored drama
+type Outcome string
+
+const (
+	Applied   Outcome = "applied"   // Confident labels written as classifier genres
+	Queued    Outcome = "queued"    // Sent to the admin review queue
+	Locked    Outcome = "locked"    // Admin-locked genres, left alone
+	Unchanged Outcome = "unchanged" // Same labels as before, or review already resolved
+)
+
+// Apply classifies a stored drama. Confident results replace its classifier
+// genres; others are queued for review. Locked dramas are never touched.
+func Apply(db *gorm.DB, drama models.Drama) (Result, Outcome, error) {
+	if drama.GenresLocked {
+		return Result{}, Locked, nil
+	}
+
+	result := Classify(drama.Judul, drama.Deskripsi)
+	if !result.Confident() {
+		if models.ReviewResolved(db, drama.BookID) {
+			return result, Unchanged, nil
+		}
+		return result, Queued, models.QueueReview(db, drama.BookID, drama.Judul, result.Suggestions())
+	}
+
+	if sameGenres(db, drama.BookID, result.Genres()) {
+		return result, Unchanged, nil
+	}
+	if err := models.SetDramaGenres(db, drama.BookID, models.GenreSourceClassifier, result.Genres()); err != nil {
+		return result, "", err
+	}
+	// A confident result supersedes an earlier low-confidence guess
+	db.Where("book_id = ? AND status = ?", drama.BookID, models.ReviewPending).Delete(&models.GenreReview{})
+	return result, Applied, nil
+}
+
+// sameGenres reports whether the drama's classifier links already match names
+func sameGenres(db *gorm.DB, bookID string, names []string) bool {
+	var slugs []string
+	db.Model(&models.DramaGenre{}).
+		Joins("JOIN genres ON genres.id = drama_genres.genre_id").
+		Where("drama_genres.book_id = ? AND drama_genres.source = ?", bookID, models.GenreSourceClassifier).
+		Pluck("genres.slug", &slugs)
+	if len(slugs) != len(names) {
+		return false
+	}
+	have := make(map[string]bool, len(slugs))
+	for _, s := range slugs {
+		have[s] = true
+	}
+	for _, n := range names {
+		if !have[models.Slugify(n)] {
+			return false
+		}
+	}
+	return true
+}
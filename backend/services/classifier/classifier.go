@@ -0,0 +1,223 @@
+// Package classifier guesses genres for a drama from its title and description
+// using weighted keywords. Results are deterministic and carry the matches
+// that produced them so admins can see why a label was chosen.
+package classifier
+
+import (
+	"dramabang/models"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const (
+	// TitleWeight multiplies keyword hits in the title over the description
+	TitleWeight = 2.0
+	// MaxLabels is how many genres one drama may receive
+	MaxLabels = 3
+	// ReviewBelow sends results whose top confidence is lower to the review queue
+	ReviewBelow = 0.5
+
+	// minShare drops secondary labels scoring under this fraction of the top one
+	minShare = 0.5
+	// saturation is the score at which confidence from evidence alone reaches 50%
+	saturation = 3.0
+)
+
+// Keyword is a word or phrase with the weight one hit adds
+type Keyword struct {
+	Text   string
+	Weight float64
+}
+
+// Rule lists the keywords for one curated genre
+type Rule struct {
+	Genre    string // Curated genre name, see models.curatedGenres
+	Keywords []Keyword
+}
+
+// Rules are checked in order; ties between genres go to the earlier rule
+var Rules = []Rule{
+	{"Epic Fantasy & Mythology", []Keyword{
+		{"naga", 3}, {"dewa", 3}, {"dewi", 2}, {"legenda", 2}, {"sakti", 2}, {"myth", 3}, {"fantasy", 3},
+		{"abadi", 1}, {"siluman", 3}, {"langit", 1}, {"bidadari", 3}, {"iblis", 2}, {"kultivasi", 3}, {"immortal", 3},
+	}},
+	{"Martial Arts Action", []Keyword{
+		{"pendekar", 3}, {"kungfu", 3}, {"silat", 3}, {"jurus", 3}, {"pedang", 2}, {"warrior", 2},
+		{"fight", 1}, {"jagoan", 2}, {"master", 1}, {"bela diri", 3}, {"sekte", 2},
+	}},
+	{"Historical Romance", []Keyword{
+		{"kerajaan", 2}, {"selir", 3}, {"kaisar", 3}, {"putri", 1}, {"pangeran", 2}, {"dynasty", 3},
+		{"dinasti", 3}, {"colossal", 2}, {"takhta", 2}, {"ratu", 1}, {"permaisuri", 3},
+	}},
+	{"Palace Intrigue & Politics", []Keyword{
+		{"intrik", 3}, {"politik", 3}, {"kudeta", 3}, {"pengkhianatan", 2}, {"hasutan", 2},
+		{"skandal", 1}, {"istana", 2}, {"menteri", 2},
+	}},
+	{"Modern Romance & CEO", []Keyword{
+		{"ceo", 3}, {"bos", 2}, {"presdir", 3}, {"cinta", 1}, {"nikah", 1}, {"menikah", 1}, {"kontrak", 2},
+		{"sekretaris", 2}, {"miliarder", 3}, {"kaya", 1}, {"wealthy", 2}, {"billionaire", 3}, {"pernikahan kontrak", 3},
+		{"selingkuh", 1}, {"perceraian", 1},
+	}},
+	{"School & Youth", []Keyword{
+		{"sekolah", 3}, {"kampus", 3}, {"mahasiswa", 3}, {"sma", 3}, {"kuliah", 2}, {"youth", 2},
+		{"remaja", 2}, {"cinta pertama", 2}, {"kelas", 1}, {"siswa", 2},
+	}},
+	{"Mystery & Detective", []Keyword{
+		{"misteri", 3}, {"detektif", 3}, {"pembunuhan", 3}, {"kasus", 1}, {"investigasi", 3}, {"rahasia", 1},
+		{"hilang", 1}, {"crime", 2}, {"polisi", 2}, {"pembunuh", 3},
+	}},
+	{"E-Sports & Gaming", []Keyword{
+		{"game", 3}, {"esport", 3}, {"esports", 3}, {"gamer", 3}, {"kompetisi", 1}, {"online", 1},
+		{"avatar", 2}, {"dunia maya", 3},
+	}},
+}
+
+// particles are Indonesian clitics that may follow a keyword ("rahasianya", "bosku")
+var particles = []string{"nya", "lah", "kah", "pun", "ku", "mu"}
+
+// Tokenize lowercases text and splits it into words of letters and digits
+func Tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
+
+// wordMatches compares a text word with a keyword word, allowing a trailing particle
+func wordMatches(token, word string) bool {
+	if token == word {
+		return true
+	}
+	if !strings.HasPrefix(token, word) {
+		return false
+	}
+	rest := token[len(word):]
+	for _, p := range particles {
+		if rest == p {
+			return true
+		}
+	}
+	return false
+}
+
+// Label is one scored genre with the evidence behind it
+type Label struct {
+	Genre      string
+	Score      float64
+	Confidence float64
+	Matches    []string
+}
+
+// Result is the classifier's verdict for one drama
+type Result struct {
+	Labels []Label // Best first, at most MaxLabels
+}
+
+// Confident reports whether the result can be applied without review
+func (r Result) Confident() bool {
+	return len(r.Labels) > 0 && r.Labels[0].Confidence >= ReviewBelow
+}
+
+// Suggestions converts the labels for storage on a review
+func (r Result) Suggestions() []models.GenreSuggestion {
+	out := make([]models.GenreSuggestion, len(r.Labels))
+	for i, l := range r.Labels {
+		out[i] = models.GenreSuggestion{
+			Slug:       models.Slugify(l.Genre),
+			Name:       l.Genre,
+			Score:      l.Score,
+			Confidence: l.Confidence,
+			Matches:    l.Matches,
+		}
+	}
+	return out
+}
+
+// Genres returns the label names
+func (r Result) Genres() []string {
+	names := make([]string, len(r.Labels))
+	for i, l := range r.Labels {
+		names[i] = l.Genre
+	}
+	return names
+}
+
+// Classify scores every rule against the title and description
+func Classify(title, description string) Result {
+	fields := []struct {
+		name   string
+		tokens []string
+		weight float64
+	}{
+		{"title", Tokenize(title), TitleWeight},
+		{"description", Tokenize(description), 1},
+	}
+
+	var labels []Label
+	total := 0.0
+	for _, rule := range Rules {
+		label := Label{Genre: rule.Genre}
+		for _, kw := range rule.Keywords {
+			phrase := Tokenize(kw.Text)
+			for _, f := range fields {
+				// Each keyword counts once per field so repetition doesn't dominate
+				if containsPhrase(f.tokens, phrase) {
+					add := kw.Weight * f.weight
+					label.Score += add
+					label.Matches = append(label.Matches, fmt.Sprintf("%s:%s (+%g)", f.name, kw.Text, add))
+				}
+			}
+		}
+		if label.Score > 0 {
+			labels = append(labels, label)
+			total += label.Score
+		}
+	}
+	if len(labels) == 0 {
+		return Result{}
+	}
+
+	sort.SliceStable(labels, func(i, j int) bool { return labels[i].Score > labels[j].Score })
+
+	top := labels[0].Score
+	kept := []Label{labels[0]}
+	for _, l := range labels[1:] {
+		if len(kept) == MaxLabels || l.Score < top*minShare {
+			break
+		}
+		kept = append(kept, l)
+	}
+
+	// Confidence combines how much evidence a label has with how much of the
+	// total evidence pointed at genres that were dropped
+	keptScore := 0.0
+	for _, l := range kept {
+		keptScore += l.Score
+	}
+	for i := range kept {
+		evidence := kept[i].Score / (kept[i].Score + saturation)
+		kept[i].Confidence = math.Round(evidence*keptScore/total*100) / 100
+	}
+	return Result{Labels: kept}
+}
+
+func containsPhrase(tokens, phrase []string) bool {
+	if len(phrase) == 0 {
+		return false
+	}
+	for i := 0; i+len(phrase) <= len(tokens); i++ {
+		match := true
+		for j, w := range phrase {
+			if !wordMatches(tokens[i+j], w) {
+				match = false
+				break
+			}
+		}
+		if match {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,283 @@
+package credentials
+
+import (
+	"dramabang/models"
+	"dramabang/utils"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Settings keys for credentials look like "credential:<id>"
+const keyPrefix = "credential:"
+
+const (
+	StatusActive  = "active"
+	StatusFailed  = "failed"
+	StatusRevoked = "revoked"
+)
+
+// FailedCooldown is how long a rejected token sits out before it is retried
+const FailedCooldown = 15 * time.Minute
+
+// sapimuProviders share the sapimu.au bearer token
+var sapimuProviders = []string{"freeshort", "netshort"}
+
+// Credential is an upstream API token for one provider
+type Credential struct {
+	ID        string     `json:"id"`
+	Provider  string     `json:"provider"`
+	Label     string     `json:"label"`
+	Token     string     `json:"token"`
+	Status    string     `json:"status"`
+	Failures  int        `json:"failures"`
+	LastError string     `json:"last_error"`
+	FailedAt  *time.Time `json:"failed_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// Masked returns a copy safe to show in the admin panel
+func (c Credential) Masked() Credential {
+	if len(c.Token) > 8 {
+		c.Token = c.Token[:4] + strings.Repeat("*", 8) + c.Token[len(c.Token)-4:]
+	} else {
+		c.Token = strings.Repeat("*", len(c.Token))
+	}
+	return c
+}
+
+func (c *Credential) usable(now time.Time) bool {
+	switch c.Status {
+	case StatusActive:
+		return true
+	case StatusFailed:
+		return c.FailedAt == nil || now.Sub(*c.FailedAt) > FailedCooldown
+	}
+	return false
+}
+
+// Store keeps decrypted credentials in memory and persists them encrypted in settings
+type Store struct {
+	db *gorm.DB
+
+	mu    sync.Mutex
+	creds map[string]*Credential // id -> credential
+	next  map[string]int         // provider -> round robin cursor
+}
+
+// Init loads the vault from settings and seeds the sapimu token from
+// SAPIMU_TOKEN on first run. It fails when no encryption key is configured.
+func Init(db *gorm.DB) (*Store, error) {
+	if err := utils.CheckEncryptionKey(); err != nil {
+		return nil, err
+	}
+	s := &Store{
+		db:    db,
+		creds: make(map[string]*Credential),
+		next:  make(map[string]int),
+	}
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+
+	seed := os.Getenv("SAPIMU_TOKEN")
+	for _, provider := range sapimuProviders {
+		if len(s.forProvider(provider)) > 0 {
+			continue
+		}
+		if seed == "" {
+			msg := fmt.Sprintf("No %s credential: set SAPIMU_TOKEN or add one in the admin panel", provider)
+			fmt.Println(msg)
+			models.LogError(db, msg)
+			continue
+		}
+		if _, err := s.Add(provider, "seeded", seed); err != nil {
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
+
+func (s *Store) load() error {
+	var rows []models.Setting
+	if err := s.db.Where("key LIKE ?", keyPrefix+"%").Find(&rows).Error; err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		plain, err := utils.DecryptString(row.Value)
+		if err != nil {
+			models.LogError(s.db, fmt.Sprintf("Credential %s could not be decrypted (key changed?)", row.Key))
+			continue
+		}
+		var cred Credential
+		if err := json.Unmarshal([]byte(plain), &cred); err != nil {
+			continue
+		}
+		s.creds[cred.ID] = &cred
+	}
+	return nil
+}
+
+// save persists one credential; caller must hold s.mu
+func (s *Store) save(cred *Credential) error {
+	plain, err := json.Marshal(cred)
+	if err != nil {
+		return err
+	}
+	sealed, err := utils.EncryptString(string(plain))
+	if err != nil {
+		return err
+	}
+	return s.db.Save(&models.Setting{Key: keyPrefix + cred.ID, Value: sealed}).Error
+}
+
+// forProvider returns the provider's credentials ordered by creation; caller must hold s.mu
+func (s *Store) forProvider(provider string) []*Credential {
+	var list []*Credential
+	for _, c := range s.creds {
+		if c.Provider == provider {
+			list = append(list, c)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].CreatedAt.Before(list[j].CreatedAt) })
+	return list
+}
+
+// Next returns the next usable token for provider (round robin)
+func (s *Store) Next(provider string) (string, string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	var usable []*Credential
+	for _, c := range s.forProvider(provider) {
+		if c.usable(now) {
+			usable = append(usable, c)
+		}
+	}
+	if len(usable) == 0 {
+		return "", "", fmt.Errorf("no usable credentials for %s", provider)
+	}
+
+	cursor := s.next[provider] % len(usable)
+	s.next[provider] = cursor + 1
+	c := usable[cursor]
+	return c.ID, c.Token, nil
+}
+
+// Count returns how many usable tokens a provider has
+func (s *Store) Count(provider string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	n := 0
+	now := time.Now()
+	for _, c := range s.forProvider(provider) {
+		if c.usable(now) {
+			n++
+		}
+	}
+	return n
+}
+
+// ReportFailure marks a token as rejected by the upstream and logs it
+func (s *Store) ReportFailure(id string, status int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.creds[id]
+	if !ok {
+		return
+	}
+	now := time.Now()
+	c.Status = StatusFailed
+	c.Failures++
+	c.FailedAt = &now
+	c.LastError = fmt.Sprintf("upstream status %d", status)
+	s.save(c)
+
+	models.LogError(s.db, fmt.Sprintf("Credential %s (%s, %s) rejected with status %d", c.ID, c.Provider, c.Label, status))
+}
+
+// ReportSuccess brings a previously failed token back into rotation
+func (s *Store) ReportSuccess(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.creds[id]
+	if !ok || c.Status != StatusFailed {
+		return
+	}
+	c.Status = StatusActive
+	c.FailedAt = nil
+	s.save(c)
+
+	models.LogInfo(s.db, fmt.Sprintf("Credential %s (%s) recovered", c.ID, c.Provider))
+}
+
+// Add stores a new active token for provider
+func (s *Store) Add(provider, label, token string) (*Credential, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cred := &Credential{
+		ID:        uuid.New().String(),
+		Provider:  provider,
+		Label:     label,
+		Token:     token,
+		Status:    StatusActive,
+		CreatedAt: time.Now(),
+	}
+	if err := s.save(cred); err != nil {
+		return nil, err
+	}
+	s.creds[cred.ID] = cred
+
+	masked := cred.Masked()
+	return &masked, nil
+}
+
+// Revoke takes a token out of rotation permanently
+func (s *Store) Revoke(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.creds[id]
+	if !ok {
+		return fmt.Errorf("credential not found")
+	}
+	c.Status = StatusRevoked
+	return s.save(c)
+}
+
+// List returns all credentials with their tokens masked
+func (s *Store) List() []Credential {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	list := make([]Credential, 0, len(s.creds))
+	for _, c := range s.creds {
+		list = append(list, c.Masked())
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Provider != list[j].Provider {
+			return list[i].Provider < list[j].Provider
+		}
+		return list[i].CreatedAt.Before(list[j].CreatedAt)
+	})
+	return list
+}
+
+// IsCredentialKey reports whether a settings key belongs to the vault
+func IsCredentialKey(key string) bool {
+	return strings.HasPrefix(key, keyPrefix)
+}
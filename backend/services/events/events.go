@@ -0,0 +1,46 @@
+package events
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// NewEpisodes is emitted when ingest sees a drama's episode count go up
+type NewEpisodes struct {
+	BookID   string    `json:"bookId"`
+	Judul    string    `json:"judul"`
+	Previous int       `json:"previous"`
+	Current  int       `json:"current"`
+	At       time.Time `json:"at"`
+}
+
+var (
+	mu          sync.RWMutex
+	newEpisodes []func(NewEpisodes)
+)
+
+// OnNewEpisodes registers a handler; handlers run in their own goroutine
+func OnNewEpisodes(fn func(NewEpisodes)) {
+	mu.Lock()
+	defer mu.Unlock()
+	newEpisodes = append(newEpisodes, fn)
+}
+
+// PublishNewEpisodes notifies every registered handler
+func PublishNewEpisodes(e NewEpisodes) {
+	mu.RLock()
+	handlers := append([]func(NewEpisodes){}, newEpisodes...)
+	mu.RUnlock()
+
+	for _, fn := range handlers {
+		go func(fn func(NewEpisodes)) {
+			defer func() {
+				if p := recover(); p != nil {
+					fmt.Printf("NewEpisodes handler panicked: %v\n", p)
+				}
+			}()
+			fn(e)
+		}(fn)
+	}
+}
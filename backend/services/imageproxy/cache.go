@@ -0,0 +1,162 @@
+package imageproxy
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+// DiskCache stores encoded images on disk and evicts the least recently
+// used files once the total size exceeds maxBytes.
+type DiskCache struct {
+	dir      string
+	maxBytes int64
+
+	mu    sync.Mutex
+	size  int64
+	order *list.List               // front = most recently used
+	items map[string]*list.Element // file name -> element
+}
+
+type cacheEntry struct {
+	name string
+	size int64
+}
+
+// NewDiskCache opens (or creates) a cache directory and indexes existing files
+func NewDiskCache(dir string, maxBytes int64) (*DiskCache, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	c := &DiskCache{
+		dir:      dir,
+		maxBytes: maxBytes,
+		order:    list.New(),
+		items:    make(map[string]*list.Element),
+	}
+
+	// Seed the LRU from disk, oldest first so recent files end up in front
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	type seed struct {
+		name  string
+		size  int64
+		mtime int64
+	}
+	var seeds []seed
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		seeds = append(seeds, seed{name: e.Name(), size: info.Size(), mtime: info.ModTime().UnixNano()})
+	}
+	sort.Slice(seeds, func(i, j int) bool { return seeds[i].mtime < seeds[j].mtime })
+	for _, s := range seeds {
+		c.items[s.name] = c.order.PushFront(&cacheEntry{name: s.name, size: s.size})
+		c.size += s.size
+	}
+
+	c.mu.Lock()
+	c.evictLocked()
+	c.mu.Unlock()
+
+	return c, nil
+}
+
+// fileName maps a cache key to a stable on-disk name
+func fileName(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// Get returns the cached bytes for key, if present
+func (c *DiskCache) Get(key string) ([]byte, bool) {
+	name := fileName(key)
+
+	c.mu.Lock()
+	el, ok := c.items[name]
+	if ok {
+		c.order.MoveToFront(el)
+	}
+	c.mu.Unlock()
+	if !ok {
+		return nil, false
+	}
+
+	data, err := os.ReadFile(filepath.Join(c.dir, name))
+	if err != nil {
+		// File vanished underneath us, drop it from the index
+		c.mu.Lock()
+		c.removeLocked(name)
+		c.mu.Unlock()
+		return nil, false
+	}
+	return data, true
+}
+
+// Put writes data for key and evicts old entries if over capacity
+func (c *DiskCache) Put(key string, data []byte) error {
+	name := fileName(key)
+
+	// Write to a temp file first so readers never see partial images
+	tmp, err := os.CreateTemp(c.dir, ".tmp-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	tmp.Close()
+	if err := os.Rename(tmp.Name(), filepath.Join(c.dir, name)); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.removeLocked(name)
+	c.items[name] = c.order.PushFront(&cacheEntry{name: name, size: int64(len(data))})
+	c.size += int64(len(data))
+	c.evictLocked()
+	return nil
+}
+
+// Stats reports the number of cached files and their total size
+func (c *DiskCache) Stats() (int, int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.items), c.size
+}
+
+func (c *DiskCache) removeLocked(name string) {
+	el, ok := c.items[name]
+	if !ok {
+		return
+	}
+	c.size -= el.Value.(*cacheEntry).size
+	c.order.Remove(el)
+	delete(c.items, name)
+}
+
+func (c *DiskCache) evictLocked() {
+	for c.size > c.maxBytes && c.order.Len() > 0 {
+		el := c.order.Back()
+		entry := el.Value.(*cacheEntry)
+		os.Remove(filepath.Join(c.dir, entry.name))
+		c.removeLocked(entry.name)
+	}
+}
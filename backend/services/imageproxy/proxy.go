@@ -0,0 +1,258 @@
+package imageproxy
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	// Register decoders for upstream cover formats
+	_ "image/gif"
+	_ "image/png"
+
+	"github.com/HugoSmits86/nativewebp"
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+	"golang.org/x/sync/singleflight"
+)
+
+// DefaultAllowedHosts are the CDN domains our providers serve covers from.
+// Extra domains can be added with IMAGE_PROXY_ALLOWED_HOSTS (comma separated).
+var DefaultAllowedHosts = []string{
+	"ibyteimg.com",     // Melolo
+	"fizzopic.org",     // Melolo
+	"dramaboxdb.com",   // Dramabox
+	"farsunpteltd.com", // FlickReels
+	"dramabos.asia",
+	"sapimu.au",
+}
+
+// AllowedHosts returns DefaultAllowedHosts plus IMAGE_PROXY_ALLOWED_HOSTS
+func AllowedHosts() []string {
+	allowed := append([]string{}, DefaultAllowedHosts...)
+	for _, h := range strings.Split(os.Getenv("IMAGE_PROXY_ALLOWED_HOSTS"), ",") {
+		if h = strings.TrimSpace(strings.ToLower(h)); h != "" {
+			allowed = append(allowed, h)
+		}
+	}
+	return allowed
+}
+
+// HostAllowed reports whether rawURL is an http(s) URL on one of hosts or their subdomains
+func HostAllowed(rawURL string, hosts []string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return false
+	}
+	host := strings.ToLower(u.Hostname())
+	for _, a := range hosts {
+		if host == a || strings.HasSuffix(host, "."+a) {
+			return true
+		}
+	}
+	return false
+}
+
+// Widths are the resize buckets we serve; requests snap to the nearest one
+// so the cache only holds a handful of variants per image.
+var Widths = []int{160, 320, 480, 720, 1080}
+
+const (
+	FormatJPEG = "jpg"
+	FormatWebP = "webp"
+
+	maxSourceBytes = 10 << 20 // 10MB
+	maxSourceSide  = 8000     // Pixels; larger images are refused before decoding
+	maxRedirects   = 5
+	jpegQuality    = 82
+)
+
+// Image is an encoded, ready-to-serve image
+type Image struct {
+	Data        []byte
+	ContentType string
+	ETag        string
+}
+
+// Proxy fetches, resizes and caches upstream images
+type Proxy struct {
+	client  *http.Client
+	cache   *DiskCache
+	allowed []string
+	group   singleflight.Group
+}
+
+// New builds a proxy from env: IMAGE_CACHE_DIR, IMAGE_CACHE_MAX_MB, IMAGE_PROXY_ALLOWED_HOSTS
+func New() (*Proxy, error) {
+	dir := os.Getenv("IMAGE_CACHE_DIR")
+	if dir == "" {
+		dir = "data/imgcache"
+	}
+
+	maxMB, _ := strconv.Atoi(os.Getenv("IMAGE_CACHE_MAX_MB"))
+	if maxMB <= 0 {
+		maxMB = 512
+	}
+
+	cache, err := NewDiskCache(dir, int64(maxMB)<<20)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &Proxy{cache: cache, allowed: AllowedHosts()}
+	p.client = &http.Client{
+		Timeout: 20 * time.Second,
+		// Every hop must stay on an allowed host, or a CDN redirect could
+		// point the proxy at internal addresses
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxRedirects {
+				return fmt.Errorf("too many redirects")
+			}
+			if !p.IsAllowed(req.URL.String()) {
+				return fmt.Errorf("redirect to %s not allowed", req.URL.Hostname())
+			}
+			return nil
+		},
+	}
+	return p, nil
+}
+
+// IsAllowed reports whether the URL points at an allowed upstream host
+func (p *Proxy) IsAllowed(rawURL string) bool {
+	return HostAllowed(rawURL, p.allowed)
+}
+
+// SnapWidth rounds a requested width up to the nearest bucket (0 = original)
+func SnapWidth(w int) int {
+	if w <= 0 {
+		return 0
+	}
+	for _, b := range Widths {
+		if w <= b {
+			return b
+		}
+	}
+	return Widths[len(Widths)-1]
+}
+
+// Get returns the image at rawURL resized to width and encoded as format
+func (p *Proxy) Get(rawURL string, width int, format string) (*Image, error) {
+	if !p.IsAllowed(rawURL) {
+		return nil, fmt.Errorf("host not allowed")
+	}
+	if format != FormatWebP {
+		format = FormatJPEG
+	}
+	width = SnapWidth(width)
+
+	key := fmt.Sprintf("%s|%d|%s", rawURL, width, format)
+	if data, ok := p.cache.Get(key); ok {
+		return newImage(data, format), nil
+	}
+
+	// Collapse concurrent misses for the same variant into one upstream fetch
+	v, err, _ := p.group.Do(key, func() (interface{}, error) {
+		data, err := p.render(rawURL, width, format)
+		if err != nil {
+			return nil, err
+		}
+		if err := p.cache.Put(key, data); err != nil {
+			fmt.Println("Image cache write error:", err)
+		}
+		return data, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return newImage(v.([]byte), format), nil
+}
+
+// CacheStats reports the number of cached variants and their total size
+func (p *Proxy) CacheStats() (int, int64) {
+	return p.cache.Stats()
+}
+
+func (p *Proxy) render(rawURL string, width int, format string) ([]byte, error) {
+	req, err := http.NewRequest("GET", rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36")
+	req.Header.Set("Accept", "image/webp,image/jpeg,image/png,image/*")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("upstream status: %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxSourceBytes))
+	if err != nil {
+		return nil, err
+	}
+	// Check the declared size first so a tiny file can't claim gigapixels
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("decode: %w", err)
+	}
+	if cfg.Width > maxSourceSide || cfg.Height > maxSourceSide {
+		return nil, fmt.Errorf("image too large: %dx%d", cfg.Width, cfg.Height)
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("decode: %w", err)
+	}
+
+	img := resize(src, width)
+
+	var buf bytes.Buffer
+	if format == FormatWebP {
+		err = nativewebp.Encode(&buf, img, nil)
+	} else {
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: jpegQuality})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("encode: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// resize scales src down to width keeping aspect ratio; it never upscales
+func resize(src image.Image, width int) image.Image {
+	b := src.Bounds()
+	if width <= 0 || b.Dx() <= width {
+		return src
+	}
+	height := b.Dy() * width / b.Dx()
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, b, draw.Src, nil)
+	return dst
+}
+
+func newImage(data []byte, format string) *Image {
+	sum := sha256.Sum256(data)
+	contentType := "image/jpeg"
+	if format == FormatWebP {
+		contentType = "image/webp"
+	}
+	return &Image{
+		Data:        data,
+		ContentType: contentType,
+		ETag:        `"` + hex.EncodeToString(sum[:8]) + `"`,
+	}
+}
@@ -0,0 +1,117 @@
+package jobs
+
+import (
+	"dramabang/models"
+	"dramabang/services/adapter"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// backfillBatch is how many dramas are re-parsed per transaction
+const backfillBatch = 200
+
+// Backfill parses the free-form metadata of stored dramas into the typed fields
+// (episode count, likes, language, canonical title, dub flag, series status) and
+// seeds genre links from the legacy genre column
+func Backfill(m *adapter.Manager) RunFunc {
+	return func(r *Run) error {
+		var total int64
+		r.DB.Model(&models.Drama{}).Count(&total)
+		r.Logf("Backfilling metadata for %d dramas", total)
+
+		// Published episodes per drama, used to tell ongoing from completed
+		type epCount struct {
+			BookID string
+			Count  int
+		}
+		var counts []epCount
+		if err := r.DB.Model(&models.Episode{}).Select("book_id, count(*) as count").Group("book_id").Find(&counts).Error; err != nil {
+			return err
+		}
+		published := make(map[string]int, len(counts))
+		for _, c := range counts {
+			published[c.BookID] = c.Count
+		}
+
+		var linkedIDs []string
+		r.DB.Model(&models.DramaGenre{}).Distinct("book_id").Pluck("book_id", &linkedIDs)
+		linked := make(map[string]bool, len(linkedIDs))
+		for _, id := range linkedIDs {
+			linked[id] = true
+		}
+
+		done, updated, tagged := 0, 0, 0
+		var batch []models.Drama
+		err := r.DB.Order("book_id").FindInBatches(&batch, backfillBatch, func(tx *gorm.DB, _ int) error {
+			if r.Cancelled() {
+				return r.Ctx.Err()
+			}
+			for _, d := range batch {
+				if !linked[d.BookID] && !d.GenresLocked && d.Genre != "" {
+					if tags := legacyTags(d); len(tags) > 0 {
+						if err := models.SetDramaGenres(r.DB, d.BookID, models.GenreSourceUpstream, tags); err != nil {
+							return err
+						}
+						tagged++
+					}
+				}
+
+				before := d
+				// Re-derive from the strings rather than trusting earlier values
+				d.EpisodeCount, d.LikesCount, d.Dubbed, d.AudioLanguage = 0, 0, false, ""
+				m.NormalizeStored(&d)
+				if d.SeriesStatus == "" {
+					d.SeriesStatus = models.SeriesStatusFor(d.EpisodeCount, published[d.BookID])
+				}
+
+				if d.EpisodeCount == before.EpisodeCount && d.LikesCount == before.LikesCount &&
+					d.Language == before.Language && d.Dubbed == before.Dubbed && d.SeriesStatus == before.SeriesStatus &&
+					d.TitleKey == before.TitleKey && d.CanonicalTitle == before.CanonicalTitle && d.AudioLanguage == before.AudioLanguage {
+					continue
+				}
+				if err := r.DB.Model(&models.Drama{}).Where("book_id = ?", d.BookID).Updates(map[string]interface{}{
+					"episode_count":   d.EpisodeCount,
+					"likes_count":     d.LikesCount,
+					"language":        d.Language,
+					"dubbed":          d.Dubbed,
+					"series_status":   d.SeriesStatus,
+					"canonical_title": d.CanonicalTitle,
+					"title_key":       d.TitleKey,
+					"audio_language":  d.AudioLanguage,
+				}).Error; err != nil {
+					return err
+				}
+				updated++
+			}
+			done += len(batch)
+			if total > 0 {
+				r.Progress(int(int64(done)*100/total), "Backfilled %d/%d dramas", done, total)
+			}
+			return nil
+		}).Error
+		if r.Cancelled() {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		r.SetResult(map[string]int{"scanned": done, "updated": updated, "tagged": tagged})
+		r.Progress(100, "Updated metadata on %d of %d dramas, tagged %d", updated, done, tagged)
+		return nil
+	}
+}
+
+// legacyTags splits a stored genre string, skipping placeholders that only name the provider
+func legacyTags(d models.Drama) []string {
+	var tags []string
+	for _, t := range strings.Split(d.Genre, ",") {
+		t = strings.TrimSpace(t)
+		if t == "" || adapter.ProviderCapabilities(models.Slugify(t)).Locales != nil {
+			continue
+		}
+		tags = append(tags, t)
+	}
+	return tags
+}
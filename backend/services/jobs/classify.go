@@ -0,0 +1,39 @@
+package jobs
+
+import (
+	"dramabang/models"
+	"dramabang/services/classifier"
+)
+
+// Classify runs the genre classifier over every drama without locked genres.
+// Low-confidence results go to the review queue instead of being applied.
+func Classify(r *Run) error {
+	var dramas []models.Drama
+	if err := r.DB.Select("book_id, judul, deskripsi, genres_locked").Order("book_id").Find(&dramas).Error; err != nil {
+		return err
+	}
+	r.Logf("Classifying %d dramas...", len(dramas))
+
+	counts := make(map[classifier.Outcome]int)
+	for i, drama := range dramas {
+		if r.Cancelled() {
+			return nil
+		}
+
+		_, outcome, err := classifier.Apply(r.DB, drama)
+		if err != nil {
+			r.Logf("Error classifying %s: %v", drama.BookID, err)
+			continue
+		}
+		counts[outcome]++
+
+		if i%200 == 0 {
+			r.Progress(i*100/len(dramas), "Classified %d/%d", i, len(dramas))
+		}
+	}
+
+	r.SetResult(counts)
+	r.Progress(100, "Classified %d dramas: %d changed, %d queued for review, %d locked",
+		len(dramas), counts[classifier.Applied], counts[classifier.Queued], counts[classifier.Locked])
+	return nil
+}
@@ -0,0 +1,43 @@
+package jobs
+
+import (
+	"dramabang/models"
+	"time"
+)
+
+// Retention for rows removed by the cleanup job
+const (
+	SystemLogRetention = 30 * 24 * time.Hour
+	JobRetention       = 30 * 24 * time.Hour
+	SearchLogRetention = 90 * 24 * time.Hour
+)
+
+// Cleanup deletes expired reset tokens, old system logs, old finished jobs and old search logs
+func Cleanup(r *Run) error {
+	now := time.Now()
+
+	res := r.DB.Where("expires_at < ?", now).Delete(&models.PasswordResetToken{})
+	if res.Error != nil {
+		return res.Error
+	}
+	r.Progress(25, "Deleted %d expired password reset tokens", res.RowsAffected)
+
+	res = r.DB.Where("created_at < ?", now.Add(-SystemLogRetention)).Delete(&models.SystemLog{})
+	if res.Error != nil {
+		return res.Error
+	}
+	r.Progress(50, "Deleted %d old system logs", res.RowsAffected)
+
+	res = r.DB.Where("created_at < ? AND status <> ?", now.Add(-JobRetention), models.JobRunning).Delete(&models.Job{})
+	if res.Error != nil {
+		return res.Error
+	}
+	r.Progress(75, "Deleted %d old jobs", res.RowsAffected)
+
+	res = r.DB.Where("created_at < ?", now.Add(-SearchLogRetention)).Delete(&models.SearchLog{})
+	if res.Error != nil {
+		return res.Error
+	}
+	r.Progress(100, "Deleted %d old search logs", res.RowsAffected)
+	return nil
+}
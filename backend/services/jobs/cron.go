@@ -0,0 +1,130 @@
+package jobs
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule is a parsed 5-field cron expression: minute hour day-of-month month day-of-week.
+// Fields accept "*", "*/n", "a/n", "a-b", "a-b/n" and comma lists. Day-of-week 0 and 7 are Sunday.
+type Schedule struct {
+	Expr   string
+	minute []bool
+	hour   []bool
+	dom    []bool
+	month  []bool
+	dow    []bool
+	// Standard cron: if both day fields are restricted, either may match
+	domAny bool
+	dowAny bool
+}
+
+// ParseSchedule parses a cron expression, also accepting @hourly, @daily and @weekly
+func ParseSchedule(expr string) (*Schedule, error) {
+	expr = strings.TrimSpace(expr)
+	switch expr {
+	case "@hourly":
+		expr = "0 * * * *"
+	case "@daily":
+		expr = "0 0 * * *"
+	case "@weekly":
+		expr = "0 0 * * 0"
+	}
+
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron: expected 5 fields, got %d", len(fields))
+	}
+
+	s := &Schedule{Expr: expr, domAny: fields[2] == "*", dowAny: fields[4] == "*"}
+	var err error
+	if s.minute, err = parseField(fields[0], 0, 59); err != nil {
+		return nil, fmt.Errorf("cron minute: %w", err)
+	}
+	if s.hour, err = parseField(fields[1], 0, 23); err != nil {
+		return nil, fmt.Errorf("cron hour: %w", err)
+	}
+	if s.dom, err = parseField(fields[2], 1, 31); err != nil {
+		return nil, fmt.Errorf("cron day-of-month: %w", err)
+	}
+	if s.month, err = parseField(fields[3], 1, 12); err != nil {
+		return nil, fmt.Errorf("cron month: %w", err)
+	}
+	if s.dow, err = parseField(fields[4], 0, 7); err != nil {
+		return nil, fmt.Errorf("cron day-of-week: %w", err)
+	}
+	if s.dow[7] {
+		s.dow[0] = true
+	}
+	return s, nil
+}
+
+func parseField(field string, min, max int) ([]bool, error) {
+	set := make([]bool, max+1)
+	for _, part := range strings.Split(field, ",") {
+		step, stepped := 1, false
+		if i := strings.Index(part, "/"); i >= 0 {
+			n, err := strconv.Atoi(part[i+1:])
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("bad step in %q", part)
+			}
+			step, stepped = n, true
+			part = part[:i]
+		}
+
+		lo, hi := min, max
+		if part != "*" {
+			if i := strings.Index(part, "-"); i >= 0 {
+				a, err1 := strconv.Atoi(part[:i])
+				b, err2 := strconv.Atoi(part[i+1:])
+				if err1 != nil || err2 != nil {
+					return nil, fmt.Errorf("bad range %q", part)
+				}
+				lo, hi = a, b
+			} else {
+				n, err := strconv.Atoi(part)
+				if err != nil {
+					return nil, fmt.Errorf("bad value %q", part)
+				}
+				lo, hi = n, n
+				if stepped {
+					// "n/step" runs from n to the end of the field
+					hi = max
+				}
+			}
+		}
+		if lo < min || hi > max || lo > hi {
+			return nil, fmt.Errorf("%q out of range %d-%d", part, min, max)
+		}
+		for v := lo; v <= hi; v += step {
+			set[v] = true
+		}
+	}
+	return set, nil
+}
+
+// Matches reports whether t (truncated to the minute) is a scheduled time
+func (s *Schedule) Matches(t time.Time) bool {
+	if !s.minute[t.Minute()] || !s.hour[t.Hour()] || !s.month[int(t.Month())] {
+		return false
+	}
+	domOK := s.dom[t.Day()]
+	dowOK := s.dow[int(t.Weekday())]
+	if s.domAny || s.dowAny {
+		return domOK && dowOK
+	}
+	return domOK || dowOK
+}
+
+// Next returns the first scheduled minute after t (zero if none within a year)
+func (s *Schedule) Next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	for limit := t.AddDate(1, 0, 0); t.Before(limit); t = t.Add(time.Minute) {
+		if s.Matches(t) {
+			return t
+		}
+	}
+	return time.Time{}
+}
@@ -0,0 +1,85 @@
+package jobs
+
+import (
+	"dramabang/models"
+	"dramabang/services/titles"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// dedupKey groups copies of one release: same provider, same canonical title and
+// same audio variant. Copies on other providers are kept as alternates.
+func dedupKey(d models.Drama) string {
+	provider := "dramabox" // Legacy unprefixed IDs
+	if i := strings.Index(d.BookID, ":"); i >= 0 {
+		provider = d.BookID[:i]
+	}
+	return fmt.Sprintf("%s|%s|%t|%s", provider, d.TitleKey, d.Dubbed, d.AudioLanguage)
+}
+
+// Dedup removes dramas that are the same release under a noisy title variant,
+// keeping the highest book_id of each group
+func Dedup(r *Run) error {
+	var dramas []models.Drama
+	if err := r.DB.Select("book_id, judul, title_key, dubbed, audio_language").Find(&dramas).Error; err != nil {
+		return err
+	}
+
+	// Rows stored before title normalization get their key now
+	filled := 0
+	groups := make(map[string][]string)
+	for _, d := range dramas {
+		if d.TitleKey == "" {
+			info := titles.Parse(d.Judul)
+			d.TitleKey, d.Dubbed, d.AudioLanguage = info.Key, info.Dubbed, info.AudioLanguage
+			if d.TitleKey == "" {
+				continue
+			}
+			r.DB.Model(&models.Drama{}).Where("book_id = ?", d.BookID).Updates(map[string]interface{}{
+				"canonical_title": info.Canonical,
+				"title_key":       info.Key,
+				"dubbed":          info.Dubbed,
+				"audio_language":  info.AudioLanguage,
+			})
+			filled++
+		}
+		key := dedupKey(d)
+		groups[key] = append(groups[key], d.BookID)
+	}
+	if filled > 0 {
+		r.Logf("Normalized %d titles", filled)
+	}
+
+	var dupes [][]string
+	for _, ids := range groups {
+		if len(ids) > 1 {
+			sort.Sort(sort.Reverse(sort.StringSlice(ids)))
+			dupes = append(dupes, ids)
+		}
+	}
+	r.Logf("Found %d titles with duplicates", len(dupes))
+
+	deleted := 0
+	for i, ids := range dupes {
+		if r.Cancelled() {
+			return nil
+		}
+
+		// Keep the first one (latest book_id), delete the rest
+		for _, id := range ids[1:] {
+			r.Logf("Deleting duplicate of %s: %s", ids[0], id)
+			r.DB.Where("book_id = ?", id).Delete(&models.Drama{})
+			r.DB.Where("book_id = ?", id).Delete(&models.Episode{})
+			r.DB.Where("book_id = ?", id).Delete(&models.DramaGenre{})
+			deleted++
+		}
+
+		if i%50 == 0 {
+			r.Progress(i*100/len(dupes), "Deduplicating %d/%d titles", i+1, len(dupes))
+		}
+	}
+
+	r.Progress(100, "Removed %d duplicates across %d titles", deleted, len(dupes))
+	return nil
+}
@@ -0,0 +1,444 @@
+package jobs
+
+import (
+	"dramabang/models"
+	"dramabang/services/adapter"
+	"dramabang/services/classifier"
+	"dramabang/services/events"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Settings keys used by the ingest job
+const (
+	// IngestConfigKey holds IngestConfig as JSON, e.g. {"max_pages": 10, "depth": {"dramabox": 30}}
+	IngestConfigKey = "ingest_config"
+	// ingestCheckpointKey records progress so a crashed or cancelled run resumes where it stopped
+	ingestCheckpointKey = "ingest_checkpoint"
+)
+
+// DefaultIngestPages is how many latest pages are walked per provider
+const DefaultIngestPages = 5
+
+// Dramas not seen in any feed for StaleAfter get their detail re-checked,
+// at most RecheckPerRun per ingest, to detect upstream delisting
+const (
+	StaleAfter    = 7 * 24 * time.Hour
+	RecheckPerRun = 100
+)
+
+// IngestConfig controls how deep ingest walks each provider
+type IngestConfig struct {
+	MaxPages int            `json:"max_pages"` // Default depth for every provider
+	Depth    map[string]int `json:"depth"`     // Per-provider override
+}
+
+func (c IngestConfig) pagesFor(providerID string) int {
+	if d, ok := c.Depth[providerID]; ok && d > 0 {
+		return d
+	}
+	if c.MaxPages > 0 {
+		return c.MaxPages
+	}
+	return DefaultIngestPages
+}
+
+// ingestCheckpoint is saved after every page
+type ingestCheckpoint struct {
+	Done     []string                  `json:"done"`     // Providers finished in this pass
+	Provider string                    `json:"provider"` // Provider in progress
+	Page     int                       `json:"page"`     // Last completed page of Provider
+	Counts   map[string]*ProviderCount `json:"counts"`
+}
+
+// ProviderCount is the per-provider summary reported by ingest
+type ProviderCount struct {
+	Pages    int `json:"pages"`
+	Seen     int `json:"seen"`
+	New      int `json:"new"`
+	Updated  int `json:"updated"`
+	Details  int `json:"details"`
+	Episodes int `json:"episodes"`
+	Grew     int `json:"grew"`          // Dramas whose episode count went up
+	Queued   int `json:"review_queued"` // New dramas sent to the genre review queue
+	Errors   int `json:"errors"`
+}
+
+func loadSetting(db *gorm.DB, key string, v interface{}) bool {
+	var setting models.Setting
+	if err := db.Where("key = ?", key).First(&setting).Error; err != nil {
+		return false
+	}
+	return json.Unmarshal([]byte(setting.Value), v) == nil
+}
+
+func saveSetting(db *gorm.DB, key string, v interface{}) error {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return db.Save(&models.Setting{Key: key, Value: string(raw)}).Error
+}
+
+// listingChanged reports whether feed data differs from the stored row
+func listingChanged(old, fresh models.Drama) bool {
+	return (fresh.Judul != "" && fresh.Judul != old.Judul) ||
+		(fresh.Cover != "" && fresh.Cover != old.Cover) ||
+		(fresh.TotalEpisode != "" && fresh.TotalEpisode != old.TotalEpisode)
+}
+
+// upsertDrama saves listing fields, keeping admin-edited flags. The genre column
+// is derived from the taxonomy once a drama exists, so upstream tags go through
+// SetDramaGenres instead of overwriting it.
+func upsertDrama(db *gorm.DB, drama models.Drama) error {
+	drama.Episodes = nil
+	tags := drama.Tags
+	columns := []string{"judul", "cover", "deskripsi", "total_episode", "dubbed",
+		"canonical_title", "title_key", "audio_language"}
+	// Listings carry less metadata than detail; keep what an earlier detail call stored
+	if drama.EpisodeCount > 0 {
+		columns = append(columns, "episode_count")
+	}
+	if drama.LikesCount > 0 {
+		columns = append(columns, "likes", "likes_count")
+	}
+	if drama.ViewsCount > 0 {
+		columns = append(columns, "views_count")
+	}
+	if drama.ReleasedAt != nil {
+		columns = append(columns, "released_at")
+	}
+	if drama.Language != "" {
+		columns = append(columns, "language")
+	}
+	if drama.SeriesStatus != "" {
+		columns = append(columns, "series_status")
+	}
+	if err := db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "book_id"}},
+		DoUpdates: clause.AssignmentColumns(columns),
+	}).Create(&drama).Error; err != nil {
+		return err
+	}
+	if len(tags) == 0 {
+		return nil
+	}
+	// Admin-edited genres win over upstream tags
+	var stored models.Drama
+	if err := db.Select("genres_locked").Where("book_id = ?", drama.BookID).First(&stored).Error; err != nil || stored.GenresLocked {
+		return err
+	}
+	return models.SetDramaGenres(db, drama.BookID, models.GenreSourceUpstream, tags)
+}
+
+// replaceEpisodes swaps a drama's stored episode list in one transaction
+func replaceEpisodes(db *gorm.DB, bookID string, episodes []models.Episode) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("book_id = ?", bookID).Delete(&models.Episode{}).Error; err != nil {
+			return err
+		}
+		if len(episodes) == 0 {
+			return nil
+		}
+		rows := make([]models.Episode, len(episodes))
+		for i, ep := range episodes {
+			ep.ID = 0
+			ep.BookID = bookID
+			rows[i] = ep
+		}
+		return tx.CreateInBatches(rows, 200).Error
+	})
+}
+
+// Ingest walks every enabled provider's latest pages (plus trending), upserting
+// dramas and, for new or changed ones, their episodes
+func Ingest(m *adapter.Manager) RunFunc {
+	return func(r *Run) error {
+		var cfg IngestConfig
+		loadSetting(r.DB, IngestConfigKey, &cfg)
+
+		var cp ingestCheckpoint
+		if loadSetting(r.DB, ingestCheckpointKey, &cp) {
+			r.Logf("Resuming from checkpoint: %d providers done, %s at page %d", len(cp.Done), cp.Provider, cp.Page)
+		}
+		if cp.Counts == nil {
+			cp.Counts = make(map[string]*ProviderCount)
+		}
+		done := make(map[string]bool)
+		for _, id := range cp.Done {
+			done[id] = true
+		}
+
+		ids := m.ActiveProviderIDs()
+		for i, id := range ids {
+			if done[id] {
+				continue
+			}
+			if r.Cancelled() {
+				return nil
+			}
+
+			count := cp.Counts[id]
+			if count == nil {
+				count = &ProviderCount{}
+				cp.Counts[id] = count
+			}
+
+			startPage := 1
+			if cp.Provider == id {
+				startPage = cp.Page + 1
+			}
+			pages := cfg.pagesFor(id)
+			if !adapter.ProviderCapabilities(id).LatestPaging {
+				// Page argument is ignored upstream, later pages would repeat page 1
+				pages = 1
+			}
+
+			r.Progress(i*100/len(ids), "Ingesting %s (%d/%d), pages %d-%d", id, i+1, len(ids), startPage, pages)
+
+			if startPage == 1 {
+				ingestFeed(r, m, id, "trending", 1, count)
+			}
+			for page := startPage; page <= pages; page++ {
+				if r.Cancelled() {
+					return nil
+				}
+				fresh := ingestFeed(r, m, id, "latest", page, count)
+
+				cp.Provider, cp.Page = id, page
+				if err := saveSetting(r.DB, ingestCheckpointKey, cp); err != nil {
+					r.Logf("Failed to save checkpoint: %v", err)
+				}
+				if fresh == 0 {
+					// Caught up with what we already have
+					break
+				}
+			}
+
+			r.Logf("%s: %d pages, %d seen, %d new, %d updated, %d details, %d episodes, %d grew, %d errors",
+				id, count.Pages, count.Seen, count.New, count.Updated, count.Details, count.Episodes, count.Grew, count.Errors)
+
+			cp.Done = append(cp.Done, id)
+			cp.Provider, cp.Page = "", 0
+			saveSetting(r.DB, ingestCheckpointKey, cp)
+			r.SetResult(cp.Counts)
+		}
+
+		// Full pass finished, next run starts over
+		r.DB.Delete(&models.Setting{}, "key = ?", ingestCheckpointKey)
+
+		recheckStale(r, m, ids)
+
+		total := ProviderCount{}
+		for _, c := range cp.Counts {
+			total.New += c.New
+			total.Updated += c.Updated
+			total.Episodes += c.Episodes
+		}
+		r.SetResult(cp.Counts)
+		r.Progress(100, "Ingested %d providers: %d new, %d updated, %d episodes", len(ids), total.New, total.Updated, total.Episodes)
+		return nil
+	}
+}
+
+// ingestFeed stores one feed page and returns how many dramas were new or changed
+func ingestFeed(r *Run, m *adapter.Manager, providerID, feed string, page int, count *ProviderCount) int {
+	dramas, err := m.FetchFeed(adapter.DefaultLocale, providerID, feed, page)
+	if err != nil {
+		r.Logf("Error fetching %s page %d from %s: %v", feed, page, providerID, err)
+		count.Errors++
+		return 0
+	}
+	count.Pages++
+	count.Seen += len(dramas)
+
+	bookIDs := make([]string, len(dramas))
+	for i, d := range dramas {
+		bookIDs[i] = d.BookID
+	}
+	existing := make(map[string]models.Drama)
+	var rows []models.Drama
+	r.DB.Where("book_id IN ?", bookIDs).Find(&rows)
+	for _, d := range rows {
+		existing[d.BookID] = d
+	}
+
+	// Dramas that already have episodes stored
+	var withEpisodes []string
+	r.DB.Model(&models.Episode{}).Where("book_id IN ?", bookIDs).Distinct().Pluck("book_id", &withEpisodes)
+	hasEpisodes := make(map[string]bool)
+	for _, id := range withEpisodes {
+		hasEpisodes[id] = true
+	}
+
+	fresh := 0
+	canDetail := adapter.ProviderCapabilities(providerID).Detail
+	for _, d := range dramas {
+		if r.Cancelled() {
+			break
+		}
+
+		old, known := existing[d.BookID]
+		changed := known && listingChanged(old, d)
+		// Without a detail endpoint there is nothing more to fetch for an unchanged drama
+		if known && !changed && (hasEpisodes[d.BookID] || !canDetail) {
+			continue
+		}
+		fresh++
+
+		if canDetail {
+			if err := ingestDetail(r, m, d, count); err != nil {
+				r.Logf("Error fetching detail for %s: %v", d.BookID, err)
+				count.Errors++
+			}
+		} else if err := upsertDrama(r.DB, d); err != nil {
+			r.Logf("Error saving %s: %v", d.BookID, err)
+			count.Errors++
+			continue
+		} else {
+			recordHistory(r, d, 0, count)
+		}
+
+		if known {
+			count.Updated++
+		} else {
+			count.New++
+			classifyNew(r, d.BookID, count)
+		}
+	}
+
+	// Listed upstream, so still available
+	models.MarkSeen(r.DB, bookIDs...)
+	return fresh
+}
+
+// ingestDetail fetches full metadata and episodes, falling back to the listing row
+func ingestDetail(r *Run, m *adapter.Manager, listing models.Drama, count *ProviderCount) error {
+	drama, episodes, err := m.FetchDetail(adapter.DefaultLocale, listing.BookID)
+	if adapter.IsNotFound(err) {
+		models.MarkFailure(r.DB, listing.BookID)
+	}
+	if err != nil || drama == nil {
+		if saveErr := upsertDrama(r.DB, listing); saveErr != nil {
+			return saveErr
+		}
+		recordHistory(r, listing, 0, count)
+		if err == nil {
+			err = fmt.Errorf("empty detail")
+		}
+		return err
+	}
+	count.Details++
+
+	// Detail responses sometimes omit fields the listing has
+	if drama.Cover == "" {
+		drama.Cover = listing.Cover
+	}
+	if drama.Genre == "" {
+		drama.Genre = listing.Genre
+	}
+	if len(drama.Tags) == 0 {
+		drama.Tags = listing.Tags
+	}
+	if drama.TotalEpisode == "" && len(episodes) > 0 {
+		drama.TotalEpisode = fmt.Sprintf("%d", len(episodes))
+	}
+	drama.BookID = listing.BookID
+
+	if err := upsertDrama(r.DB, *drama); err != nil {
+		return err
+	}
+	if err := replaceEpisodes(r.DB, listing.BookID, episodes); err != nil {
+		return err
+	}
+	count.Episodes += len(episodes)
+	recordHistory(r, *drama, len(episodes), count)
+	return nil
+}
+
+// classifyNew runs the genre classifier on a freshly stored drama
+func classifyNew(r *Run, bookID string, count *ProviderCount) {
+	var drama models.Drama
+	if err := r.DB.Select("book_id, judul, deskripsi, genres_locked").Where("book_id = ?", bookID).First(&drama).Error; err != nil {
+		return
+	}
+	_, outcome, err := classifier.Apply(r.DB, drama)
+	if err != nil {
+		r.Logf("Error classifying %s: %v", bookID, err)
+		return
+	}
+	if outcome == classifier.Queued {
+		count.Queued++
+	}
+}
+
+// recordHistory snapshots the drama and announces new episodes
+func recordHistory(r *Run, drama models.Drama, episodeCount int, count *ProviderCount) {
+	prev, increased := models.RecordSnapshot(r.DB, drama, episodeCount)
+	if !increased {
+		return
+	}
+	if episodeCount == 0 {
+		episodeCount = models.ParseEpisodeCount(drama.TotalEpisode)
+	}
+	count.Grew++
+	r.Logf("New episodes for %s: %d -> %d", drama.BookID, prev, episodeCount)
+	events.PublishNewEpisodes(events.NewEpisodes{
+		BookID:   drama.BookID,
+		Judul:    drama.Judul,
+		Previous: prev,
+		Current:  episodeCount,
+		At:       time.Now(),
+	})
+}
+
+// recheckStale looks up dramas that dropped out of every feed so delisted
+// ones are marked and hidden
+func recheckStale(r *Run, m *adapter.Manager, providerIDs []string) {
+	query := r.DB.Where("(availability IS NULL OR availability <> ?) AND (last_seen_at IS NULL OR last_seen_at < ?)",
+		models.AvailabilityUnavailable, time.Now().Add(-StaleAfter))
+
+	// Only providers that are enabled and have a detail endpoint
+	var prefixes []string
+	for _, id := range providerIDs {
+		if adapter.ProviderCapabilities(id).Detail {
+			prefixes = append(prefixes, id)
+		}
+	}
+	if len(prefixes) == 0 {
+		return
+	}
+	var conds []string
+	var args []interface{}
+	for _, p := range prefixes {
+		conds = append(conds, "book_id LIKE ?")
+		args = append(args, p+":%")
+	}
+	query = query.Where(strings.Join(conds, " OR "), args...)
+
+	var stale []models.Drama
+	query.Order("last_seen_at asc").Limit(RecheckPerRun).Find(&stale)
+	if len(stale) == 0 {
+		return
+	}
+
+	gone := 0
+	for _, d := range stale {
+		if r.Cancelled() {
+			return
+		}
+		_, _, err := m.FetchDetail(adapter.DefaultLocale, d.BookID)
+		if err == nil {
+			models.MarkSeen(r.DB, d.BookID)
+		} else if adapter.IsNotFound(err) {
+			models.MarkFailure(r.DB, d.BookID)
+			gone++
+		}
+	}
+	r.Logf("Rechecked %d stale dramas, %d not found upstream", len(stale), gone)
+}
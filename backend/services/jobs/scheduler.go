@@ -0,0 +1,387 @@
+package jobs
+
+import (
+	"context"
+	"dramabang/models"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// maxLogBytes caps the stored log of a single job
+const maxLogBytes = 1 << 20
+
+// logFlushInterval is how often a running job's log is written to the database
+const logFlushInterval = 2 * time.Second
+
+const (
+	// LeaseTTL is how long a job's lock outlives its last heartbeat, e.g. after a crash
+	LeaseTTL = 2 * time.Minute
+	// heartbeatInterval is how often a running job renews its lock
+	heartbeatInterval = 30 * time.Second
+)
+
+var (
+	ErrAlreadyRunning = errors.New("a job of this type is already running")
+	ErrUnknownType    = errors.New("unknown job type")
+	ErrNotRunning     = errors.New("job is not running")
+)
+
+// RunFunc does the work of one job. Long loops should check r.Cancelled().
+type RunFunc func(r *Run) error
+
+// Run is the handle a RunFunc uses to report progress and write its log
+type Run struct {
+	Ctx context.Context
+	DB  *gorm.DB
+
+	mu        sync.Mutex
+	job       *models.Job
+	log       strings.Builder
+	truncated bool
+	lastFlush time.Time
+}
+
+// Logf appends a timestamped line to the job log
+func (r *Run) Logf(format string, args ...interface{}) {
+	line := fmt.Sprintf(format, args...)
+	fmt.Printf("[job %d %s] %s\n", r.job.ID, r.job.Type, line)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.log.Len() < maxLogBytes {
+		r.log.WriteString(time.Now().Format("15:04:05") + " " + line + "\n")
+	} else if !r.truncated {
+		r.log.WriteString("... log truncated\n")
+		r.truncated = true
+	}
+	if time.Since(r.lastFlush) >= logFlushInterval {
+		r.flushLocked()
+	}
+}
+
+// Progress sets the completion percentage (0-100) and a status line
+func (r *Run) Progress(pct int, format string, args ...interface{}) {
+	msg := fmt.Sprintf(format, args...)
+	r.Logf("%s", msg)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if pct < 0 {
+		pct = 0
+	} else if pct > 100 {
+		pct = 100
+	}
+	r.job.Progress = pct
+	r.job.Message = msg
+	r.flushLocked()
+}
+
+// SetResult stores a JSON summary (e.g. per-provider counts) on the job
+func (r *Run) SetResult(v interface{}) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.job.Result = string(raw)
+	r.DB.Model(&models.Job{}).Where("id = ?", r.job.ID).Update("result", r.job.Result)
+}
+
+// Cancelled reports whether the job was cancelled and should stop
+func (r *Run) Cancelled() bool {
+	return r.Ctx.Err() != nil
+}
+
+func (r *Run) flushLocked() {
+	r.job.Log = r.log.String()
+	r.DB.Model(&models.Job{}).Where("id = ?", r.job.ID).Updates(map[string]interface{}{
+		"progress": r.job.Progress,
+		"message":  r.job.Message,
+		"log":      r.job.Log,
+	})
+	r.lastFlush = time.Now()
+}
+
+type active struct {
+	jobID  uint
+	cancel context.CancelFunc
+}
+
+// ScheduleInfo is the admin view of one job type
+type ScheduleInfo struct {
+	Type    string     `json:"type"`
+	Cron    string     `json:"cron"`
+	NextRun *time.Time `json:"next_run"`
+	Running bool       `json:"running"`
+	JobID   uint       `json:"running_job_id,omitempty"`
+}
+
+// Scheduler runs registered tasks on demand or on a cron schedule, at most
+// one job per type at a time
+type Scheduler struct {
+	db *gorm.DB
+
+	mu        sync.Mutex
+	tasks     map[string]RunFunc
+	running   map[string]*active
+	schedules map[string]*Schedule
+}
+
+func New(db *gorm.DB) *Scheduler {
+	return &Scheduler{
+		db:        db,
+		tasks:     make(map[string]RunFunc),
+		running:   make(map[string]*active),
+		schedules: make(map[string]*Schedule),
+	}
+}
+
+// Register adds a task type
+func (s *Scheduler) Register(jobType string, fn RunFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tasks[jobType] = fn
+}
+
+// Trigger starts a job now. It fails with ErrAlreadyRunning if one of the
+// same type hasn't finished yet, in this process or another (a CLI tool).
+func (s *Scheduler) Trigger(jobType, trigger string) (*models.Job, error) {
+	s.mu.Lock()
+	fn, ok := s.tasks[jobType]
+	if !ok {
+		s.mu.Unlock()
+		return nil, ErrUnknownType
+	}
+	if _, busy := s.running[jobType]; busy {
+		s.mu.Unlock()
+		return nil, ErrAlreadyRunning
+	}
+
+	job, owner, err := startJob(s.db, jobType, trigger)
+	if err != nil {
+		s.mu.Unlock()
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.running[jobType] = &active{jobID: job.ID, cancel: cancel}
+	s.mu.Unlock()
+
+	snapshot := *job
+	go s.execute(ctx, cancel, job, owner, fn)
+	return &snapshot, nil
+}
+
+// startJob takes the type's lock and records a running Job row
+func startJob(db *gorm.DB, jobType, trigger string) (*models.Job, string, error) {
+	owner := uuid.NewString()
+	ok, err := models.AcquireJobLock(db, jobType, owner, LeaseTTL)
+	if err != nil {
+		return nil, "", err
+	}
+	if !ok {
+		return nil, "", ErrAlreadyRunning
+	}
+
+	now := time.Now()
+	job := &models.Job{Type: jobType, Status: models.JobRunning, Trigger: trigger, StartedAt: &now}
+	if err := db.Create(job).Error; err != nil {
+		models.ReleaseJobLock(db, jobType, owner)
+		return nil, "", err
+	}
+	models.RenewJobLock(db, jobType, owner, job.ID, LeaseTTL)
+	return job, owner, nil
+}
+
+// heartbeat renews the job's lock until done is closed
+func (s *Scheduler) heartbeat(job *models.Job, owner string, done <-chan struct{}) {
+	ticker := time.NewTicker(heartbeatInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			if !models.RenewJobLock(s.db, job.Type, owner, job.ID, LeaseTTL) {
+				fmt.Printf("[job %d %s] lost its lock\n", job.ID, job.Type)
+			}
+		}
+	}
+}
+
+func (s *Scheduler) execute(ctx context.Context, cancel context.CancelFunc, job *models.Job, owner string, fn RunFunc) {
+	run := &Run{Ctx: ctx, DB: s.db, job: job, lastFlush: time.Now()}
+	done := make(chan struct{})
+	go s.heartbeat(job, owner, done)
+
+	err := func() (err error) {
+		defer func() {
+			if p := recover(); p != nil {
+				err = fmt.Errorf("panic: %v", p)
+			}
+		}()
+		return fn(run)
+	}()
+
+	run.mu.Lock()
+	finished := time.Now()
+	job.FinishedAt = &finished
+	switch {
+	case ctx.Err() != nil:
+		job.Status = models.JobCancelled
+		job.Message = "Cancelled"
+	case err != nil:
+		job.Status = models.JobFailed
+		job.Message = err.Error()
+	default:
+		job.Status = models.JobSucceeded
+		job.Progress = 100
+	}
+	job.Log = run.log.String()
+	s.db.Save(job)
+	run.mu.Unlock()
+
+	close(done)
+	models.ReleaseJobLock(s.db, job.Type, owner)
+	cancel()
+	s.mu.Lock()
+	delete(s.running, job.Type)
+	s.mu.Unlock()
+
+	label := strings.ToUpper(job.Type[:1]) + job.Type[1:]
+	switch job.Status {
+	case models.JobSucceeded:
+		models.LogSuccess(s.db, fmt.Sprintf("%s job #%d finished: %s", label, job.ID, job.Message))
+	case models.JobFailed:
+		models.LogError(s.db, fmt.Sprintf("%s job #%d failed: %s", label, job.ID, job.Message))
+	default:
+		models.LogInfo(s.db, fmt.Sprintf("%s job #%d cancelled", label, job.ID))
+	}
+}
+
+// Cancel stops a running job; the task sees it via Run.Cancelled
+func (s *Scheduler) Cancel(jobID uint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, a := range s.running {
+		if a.jobID == jobID {
+			a.cancel()
+			return nil
+		}
+	}
+	return ErrNotRunning
+}
+
+// SetSchedules replaces all cron schedules ({type: expr}); an empty expr disables the type
+func (s *Scheduler) SetSchedules(exprs map[string]string) error {
+	next := make(map[string]*Schedule)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for jobType, expr := range exprs {
+		if _, ok := s.tasks[jobType]; !ok {
+			return fmt.Errorf("%w: %s", ErrUnknownType, jobType)
+		}
+		if strings.TrimSpace(expr) == "" {
+			continue
+		}
+		sched, err := ParseSchedule(expr)
+		if err != nil {
+			return fmt.Errorf("%s: %w", jobType, err)
+		}
+		next[jobType] = sched
+	}
+	s.schedules = next
+	return nil
+}
+
+// Schedules lists every registered type with its schedule and run state
+func (s *Scheduler) Schedules() []ScheduleInfo {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	locks := models.LiveJobLocks(s.db)
+	list := make([]ScheduleInfo, 0, len(s.tasks))
+	for jobType := range s.tasks {
+		info := ScheduleInfo{Type: jobType}
+		if sched, ok := s.schedules[jobType]; ok {
+			info.Cron = sched.Expr
+			if next := sched.Next(now); !next.IsZero() {
+				info.NextRun = &next
+			}
+		}
+		if a, ok := s.running[jobType]; ok {
+			info.Running = true
+			info.JobID = a.jobID
+		} else if l, ok := locks[jobType]; ok {
+			// Held by another process, e.g. a CLI run
+			info.Running = true
+			info.JobID = l.JobID
+		}
+		list = append(list, info)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Type < list[j].Type })
+	return list
+}
+
+// Start marks jobs left running by a previous process as failed and begins
+// checking schedules once a minute. Jobs whose lock is still being renewed
+// (a CLI run) are left alone.
+func (s *Scheduler) Start() {
+	now := time.Now()
+	live := s.db.Model(&models.JobLock{}).Select("job_id").Where("expires_at > ?", now)
+	s.db.Model(&models.Job{}).Where("status = ? AND id NOT IN (?)", models.JobRunning, live).Updates(map[string]interface{}{
+		"status":      models.JobFailed,
+		"message":     "Interrupted by server restart",
+		"finished_at": now,
+	})
+
+	go func() {
+		for {
+			// Wake at the top of each minute
+			now := time.Now()
+			time.Sleep(now.Truncate(time.Minute).Add(time.Minute).Sub(now))
+			s.tick(time.Now().Truncate(time.Minute))
+		}
+	}()
+}
+
+func (s *Scheduler) tick(t time.Time) {
+	s.mu.Lock()
+	var due []string
+	for jobType, sched := range s.schedules {
+		if sched.Matches(t) {
+			due = append(due, jobType)
+		}
+	}
+	s.mu.Unlock()
+
+	for _, jobType := range due {
+		if _, err := s.Trigger(jobType, "schedule"); err != nil {
+			fmt.Printf("Scheduled %s job skipped: %v\n", jobType, err)
+		}
+	}
+}
+
+// RunStandalone runs fn synchronously for CLI tools, still recording a Job row.
+// It fails with ErrAlreadyRunning while the server (or another CLI) runs the same type.
+func RunStandalone(db *gorm.DB, jobType string, fn RunFunc) (*models.Job, error) {
+	job, owner, err := startJob(db, jobType, "cli")
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	New(db).execute(ctx, cancel, job, owner, fn)
+	return job, nil
+}
@@ -0,0 +1,21 @@
+package jobs
+
+import (
+	"dramabang/services/sitemap"
+)
+
+// Sitemap regenerates the XML sitemaps served at /sitemap.xml
+func Sitemap(r *Run) error {
+	base := sitemap.SiteURL()
+	r.Logf("Generating sitemaps for %s", base)
+
+	res, err := sitemap.Generate(r.Ctx, r.DB, base)
+	if err != nil {
+		return err
+	}
+
+	r.Logf("Wrote %d URLs (%d dramas, %d episodes, %d genres) in %d child files",
+		res.URLs, res.Dramas, res.Episodes, res.Genres, res.Files)
+	r.SetResult(res)
+	return nil
+}
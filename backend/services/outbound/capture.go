@@ -0,0 +1,78 @@
+package outbound
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// maxCaptureBytes caps how much of each captured response body is kept
+const maxCaptureBytes = 2 << 20
+
+// CapturedResponse is a raw upstream response recorded during Capture
+type CapturedResponse struct {
+	URL    string `json:"url"`
+	Status int    `json:"status"`
+	Body   []byte `json:"-"`
+}
+
+type capture struct {
+	mu        sync.Mutex
+	responses []CapturedResponse
+}
+
+// Capture runs fn while recording every response body for providerID.
+// Meant for admin test calls: concurrent live traffic for the same provider
+// may also be captured.
+func (r *Router) Capture(providerID string, fn func()) []CapturedResponse {
+	c := &capture{}
+
+	r.mu.Lock()
+	if r.captures == nil {
+		r.captures = make(map[string]*capture)
+	}
+	r.captures[providerID] = c
+	r.mu.Unlock()
+
+	defer func() {
+		r.mu.Lock()
+		if r.captures[providerID] == c {
+			delete(r.captures, providerID)
+		}
+		r.mu.Unlock()
+	}()
+
+	fn()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]CapturedResponse{}, c.responses...)
+}
+
+// maybeCapture tees resp.Body into the active capture for providerID, if any
+func (r *Router) maybeCapture(providerID string, req *http.Request, resp *http.Response) {
+	r.mu.RLock()
+	c := r.captures[providerID]
+	r.mu.RUnlock()
+	if c == nil || resp == nil {
+		return
+	}
+
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	kept := body
+	if len(kept) > maxCaptureBytes {
+		kept = kept[:maxCaptureBytes]
+	}
+
+	// Strip the query string, it may carry tokens
+	u := *req.URL
+	u.RawQuery = ""
+
+	c.mu.Lock()
+	c.responses = append(c.responses, CapturedResponse{URL: u.String(), Status: resp.StatusCode, Body: kept})
+	c.mu.Unlock()
+}
@@ -0,0 +1,67 @@
+package outbound
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// HealthCheckInterval is how often configured proxies are probed
+const HealthCheckInterval = time.Minute
+
+// StartHealthChecks probes every proxy periodically in the background
+func (r *Router) StartHealthChecks(interval time.Duration) {
+	go func() {
+		for {
+			r.CheckAll()
+			time.Sleep(interval)
+		}
+	}()
+}
+
+// CheckAll probes each configured proxy once. Any HTTP response counts as
+// healthy; only connection-level errors mark the proxy down.
+func (r *Router) CheckAll() {
+	r.mu.RLock()
+	targets := make(map[string]*proxyState, len(r.proxies))
+	for provider, ps := range r.proxies {
+		if ps.cfg.Enabled && ps.cfg.CheckURL != "" {
+			targets[provider] = ps
+		}
+	}
+	r.mu.RUnlock()
+
+	for provider, ps := range targets {
+		err := probe(ps.transport, ps.cfg.CheckURL)
+		now := time.Now()
+
+		r.mu.Lock()
+		// Skip if the config was replaced while we were probing
+		if current, ok := r.proxies[provider]; ok && current == ps {
+			ps.lastCheck = &now
+			if err != nil {
+				if ps.healthy {
+					fmt.Printf("Proxy for %s (%s) is down, falling back to direct: %v\n", provider, ps.route, err)
+				}
+				ps.healthy = false
+				ps.failures++
+				ps.lastError = err.Error()
+			} else {
+				ps.healthy = true
+				ps.failures = 0
+				ps.lastError = ""
+			}
+		}
+		r.mu.Unlock()
+	}
+}
+
+func probe(t http.RoundTripper, checkURL string) error {
+	client := &http.Client{Transport: t, Timeout: 10 * time.Second}
+	resp, err := client.Head(checkURL)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
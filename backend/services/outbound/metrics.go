@@ -0,0 +1,124 @@
+package outbound
+
+import (
+	"net/http"
+	"sort"
+	"time"
+)
+
+// Call is one recorded upstream request
+type Call struct {
+	At        time.Time `json:"at"`
+	Route     string    `json:"route"`
+	Path      string    `json:"path"`
+	Status    int       `json:"status"`
+	LatencyMs int64     `json:"latency_ms"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// ProviderMetrics summarises a provider's outbound traffic since startup
+type ProviderMetrics struct {
+	Provider   string         `json:"provider"`
+	Calls      int            `json:"calls"`
+	Errors     int            `json:"errors"`
+	ByRoute    map[string]int `json:"by_route"`
+	LastRoute  string         `json:"last_route"`
+	LastError  string         `json:"last_error"`
+	AvgLatency int64          `json:"avg_latency_ms"`
+	Recent     []Call         `json:"recent"`
+}
+
+type providerMetrics struct {
+	calls        int
+	errors       int
+	byRoute      map[string]int
+	lastRoute    string
+	lastError    string
+	totalLatency time.Duration
+	recent       []Call // Ring buffer, newest at the end
+}
+
+// record stores one call; non-2xx/3xx responses count as errors
+func (r *Router) record(provider, route string, req *http.Request, status int, latency time.Duration, err error) {
+	r.metricsMu.Lock()
+	defer r.metricsMu.Unlock()
+
+	m, ok := r.metrics[provider]
+	if !ok {
+		m = &providerMetrics{byRoute: make(map[string]int)}
+		r.metrics[provider] = m
+	}
+
+	call := Call{
+		At:        time.Now(),
+		Route:     route,
+		Path:      req.URL.Path, // Query strings may carry tokens, keep them out
+		Status:    status,
+		LatencyMs: latency.Milliseconds(),
+	}
+	if err != nil {
+		call.Error = err.Error()
+	} else if status >= 400 {
+		call.Error = http.StatusText(status)
+	}
+
+	m.calls++
+	m.byRoute[route]++
+	m.lastRoute = route
+	m.totalLatency += latency
+	if call.Error != "" {
+		m.errors++
+		m.lastError = call.Error
+	}
+
+	m.recent = append(m.recent, call)
+	if len(m.recent) > recentCallsPerProvider {
+		m.recent = m.recent[len(m.recent)-recentCallsPerProvider:]
+	}
+}
+
+// Metrics returns a snapshot of every provider's outbound metrics
+func (r *Router) Metrics() []ProviderMetrics {
+	r.metricsMu.Lock()
+	defer r.metricsMu.Unlock()
+
+	list := make([]ProviderMetrics, 0, len(r.metrics))
+	for provider, m := range r.metrics {
+		list = append(list, snapshot(provider, m))
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Provider < list[j].Provider })
+	return list
+}
+
+// ProviderMetrics returns one provider's snapshot (zero value if it made no calls yet)
+func (r *Router) ProviderMetrics(provider string) ProviderMetrics {
+	r.metricsMu.Lock()
+	defer r.metricsMu.Unlock()
+
+	m, ok := r.metrics[provider]
+	if !ok {
+		return ProviderMetrics{Provider: provider, ByRoute: map[string]int{}, Recent: []Call{}}
+	}
+	return snapshot(provider, m)
+}
+
+func snapshot(provider string, m *providerMetrics) ProviderMetrics {
+	byRoute := make(map[string]int, len(m.byRoute))
+	for k, v := range m.byRoute {
+		byRoute[k] = v
+	}
+	var avg int64
+	if m.calls > 0 {
+		avg = (m.totalLatency / time.Duration(m.calls)).Milliseconds()
+	}
+	return ProviderMetrics{
+		Provider:   provider,
+		Calls:      m.calls,
+		Errors:     m.errors,
+		ByRoute:    byRoute,
+		LastRoute:  m.lastRoute,
+		LastError:  m.lastError,
+		AvgLatency: avg,
+		Recent:     append([]Call{}, m.recent...),
+	}
+}
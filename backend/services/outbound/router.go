@@ -0,0 +1,205 @@
+package outbound
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"sync"
+	"time"
+)
+
+// RouteDirect is the route name for calls that bypass any proxy
+const RouteDirect = "direct"
+
+// recentCallsPerProvider is the size of each provider's recent call ring
+const recentCallsPerProvider = 50
+
+// ProxyConfig is one provider's outbound proxy, stored in settings.
+// URL accepts http://, https://, socks5:// and socks5h:// (with optional user:pass@).
+type ProxyConfig struct {
+	URL      string `json:"url"`
+	CheckURL string `json:"check_url"` // Probed by health checks; defaults to the provider API host
+	Enabled  bool   `json:"enabled"`
+}
+
+// Redacted returns a copy with the proxy credentials stripped from URL
+func (c ProxyConfig) Redacted() ProxyConfig {
+	if u, err := url.Parse(c.URL); err == nil && u.User != nil {
+		c.URL = u.Scheme + "://***@" + u.Host + u.Path
+	}
+	return c
+}
+
+// ProxyStatus is the admin view of a configured proxy
+type ProxyStatus struct {
+	Provider    string     `json:"provider"`
+	Route       string     `json:"route"`
+	Enabled     bool       `json:"enabled"`
+	Healthy     bool       `json:"healthy"`
+	LastCheck   *time.Time `json:"last_check"`
+	LastError   string     `json:"last_error"`
+	CheckURL    string     `json:"check_url"`
+	Consecutive int        `json:"consecutive_failures"`
+}
+
+type proxyState struct {
+	cfg       ProxyConfig
+	route     string // Redacted proxy URL used as the metrics route name
+	transport *http.Transport
+	healthy   bool
+	lastCheck *time.Time
+	lastError string
+	failures  int
+}
+
+// Router sends each provider's requests through its proxy (if healthy) and
+// falls back to a direct connection. It also keeps per-provider call metrics.
+type Router struct {
+	direct *http.Transport
+
+	mu       sync.RWMutex
+	proxies  map[string]*proxyState
+	captures map[string]*capture // Active admin test captures by provider
+
+	metricsMu sync.Mutex
+	metrics   map[string]*providerMetrics
+}
+
+// Default is the process-wide router used by the adapters
+var Default = NewRouter()
+
+func NewRouter() *Router {
+	return &Router{
+		direct:  http.DefaultTransport.(*http.Transport).Clone(),
+		proxies: make(map[string]*proxyState),
+		metrics: make(map[string]*providerMetrics),
+	}
+}
+
+// redact strips credentials so proxy URLs can be shown and logged
+func redact(u *url.URL) string {
+	return u.Scheme + "://" + u.Host
+}
+
+// Configure replaces all proxy settings. Invalid entries are returned as errors
+// and skipped; every other provider goes direct.
+func (r *Router) Configure(cfg map[string]ProxyConfig) []error {
+	var errs []error
+	next := make(map[string]*proxyState)
+
+	for provider, pc := range cfg {
+		if pc.URL == "" {
+			continue
+		}
+		u, err := url.Parse(pc.URL)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", provider, err))
+			continue
+		}
+		switch u.Scheme {
+		case "http", "https", "socks5", "socks5h":
+		default:
+			errs = append(errs, fmt.Errorf("%s: unsupported proxy scheme %q", provider, u.Scheme))
+			continue
+		}
+
+		t := r.direct.Clone()
+		t.Proxy = http.ProxyURL(u)
+		next[provider] = &proxyState{
+			cfg:       pc,
+			route:     redact(u),
+			transport: t,
+			healthy:   true, // Optimistic until the first health check says otherwise
+		}
+	}
+
+	r.mu.Lock()
+	old := r.proxies
+	r.proxies = next
+	r.mu.Unlock()
+
+	for _, ps := range old {
+		ps.transport.CloseIdleConnections()
+	}
+	return errs
+}
+
+// Transport returns the RoundTripper adapters should use for providerID
+func (r *Router) Transport(providerID string) http.RoundTripper {
+	return &providerTransport{router: r, provider: providerID}
+}
+
+// pick returns the transport and route name for a provider's next call
+func (r *Router) pick(providerID string) (http.RoundTripper, string, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if ps, ok := r.proxies[providerID]; ok && ps.cfg.Enabled && ps.healthy {
+		return ps.transport, ps.route, true
+	}
+	return r.direct, RouteDirect, false
+}
+
+// markProxyFailure flags the provider's proxy unhealthy after a transport error
+func (r *Router) markProxyFailure(providerID string, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if ps, ok := r.proxies[providerID]; ok {
+		ps.healthy = false
+		ps.failures++
+		ps.lastError = err.Error()
+	}
+}
+
+// Proxies lists configured proxies and their health
+func (r *Router) Proxies() []ProxyStatus {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	list := make([]ProxyStatus, 0, len(r.proxies))
+	for provider, ps := range r.proxies {
+		list = append(list, ProxyStatus{
+			Provider:    provider,
+			Route:       ps.route,
+			Enabled:     ps.cfg.Enabled,
+			Healthy:     ps.healthy,
+			LastCheck:   ps.lastCheck,
+			LastError:   ps.lastError,
+			CheckURL:    ps.cfg.CheckURL,
+			Consecutive: ps.failures,
+		})
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Provider < list[j].Provider })
+	return list
+}
+
+type providerTransport struct {
+	router   *Router
+	provider string
+}
+
+func (t *providerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	rt, route, viaProxy := t.router.pick(t.provider)
+
+	start := time.Now()
+	resp, err := rt.RoundTrip(req)
+	if err != nil && viaProxy && req.Body == nil {
+		// Proxy is down or blocked: record it and retry once without it
+		t.router.record(t.provider, route, req, 0, time.Since(start), err)
+		t.router.markProxyFailure(t.provider, err)
+
+		route = RouteDirect
+		start = time.Now()
+		resp, err = t.router.direct.RoundTrip(req)
+	}
+
+	status := 0
+	if resp != nil {
+		status = resp.StatusCode
+	}
+	t.router.record(t.provider, route, req, status, time.Since(start), err)
+	t.router.maybeCapture(t.provider, req, resp)
+	return resp, err
+}
@@ -0,0 +1,189 @@
+package ratelimit
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Rule configures one bucket. Rate is requests per second, Burst the bucket
+// size and DailyQuota the max requests per calendar day (0 = unlimited).
+type Rule struct {
+	Rate       float64 `json:"rate"`
+	Burst      float64 `json:"burst"`
+	DailyQuota int     `json:"daily_quota"`
+}
+
+// Usage is a snapshot of one bucket for the admin panel
+type Usage struct {
+	Key        string  `json:"key"`
+	Rate       float64 `json:"rate"`
+	Burst      float64 `json:"burst"`
+	Tokens     float64 `json:"tokens"`
+	DailyQuota int     `json:"daily_quota"`
+	UsedToday  int     `json:"used_today"`
+	Rejected   int     `json:"rejected_today"`
+	Day        string  `json:"day"`
+}
+
+// ErrLimited is returned when a request would exceed a limit
+type ErrLimited struct {
+	Key    string
+	Reason string
+}
+
+func (e *ErrLimited) Error() string {
+	return fmt.Sprintf("rate limited (%s): %s", e.Key, e.Reason)
+}
+
+type bucket struct {
+	rule     Rule
+	tokens   float64
+	last     time.Time
+	day      string
+	used     int
+	rejected int
+}
+
+// refill tops up tokens and resets daily counters; caller holds the lock
+func (b *bucket) refill(now time.Time) {
+	if day := now.Format("2006-01-02"); day != b.day {
+		b.day = day
+		b.used = 0
+		b.rejected = 0
+	}
+	elapsed := now.Sub(b.last).Seconds()
+	b.last = now
+	b.tokens += elapsed * b.rule.Rate
+	if b.tokens > b.rule.Burst {
+		b.tokens = b.rule.Burst
+	}
+}
+
+// Limiter is a set of named token buckets ("provider:melolo", "host:dramabos.asia")
+type Limiter struct {
+	mu          sync.Mutex
+	buckets     map[string]*bucket
+	defaultRule Rule
+}
+
+// New creates a limiter; keys without an explicit rule use defaultRule
+func New(defaultRule Rule) *Limiter {
+	return &Limiter{
+		buckets:     make(map[string]*bucket),
+		defaultRule: defaultRule,
+	}
+}
+
+// Configure sets (or replaces) the rule for key, keeping today's usage
+func (l *Limiter) Configure(key string, rule Rule) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if rule.Burst < 1 {
+		rule.Burst = 1
+	}
+	b := l.bucketLocked(key)
+	b.rule = rule
+	if b.tokens > rule.Burst {
+		b.tokens = rule.Burst
+	}
+}
+
+func (l *Limiter) bucketLocked(key string) *bucket {
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{
+			rule:   l.defaultRule,
+			tokens: l.defaultRule.Burst,
+			last:   time.Now(),
+			day:    time.Now().Format("2006-01-02"),
+		}
+		l.buckets[key] = b
+	}
+	return b
+}
+
+// Acquire takes one token from every key, waiting up to maxWait for them.
+// If the wait would be longer, or a daily quota is used up, nothing is taken
+// and an *ErrLimited is returned so the caller can skip the request.
+func (l *Limiter) Acquire(maxWait time.Duration, keys ...string) error {
+	l.mu.Lock()
+
+	now := time.Now()
+	for _, key := range keys {
+		l.bucketLocked(key).refill(now)
+	}
+
+	var wait time.Duration
+	for _, key := range keys {
+		b := l.buckets[key]
+
+		if b.rule.DailyQuota > 0 && b.used >= b.rule.DailyQuota {
+			l.rejectLocked(keys)
+			l.mu.Unlock()
+			return &ErrLimited{Key: key, Reason: "daily quota exhausted"}
+		}
+
+		if b.tokens < 1 && b.rule.Rate > 0 {
+			need := time.Duration((1 - b.tokens) / b.rule.Rate * float64(time.Second))
+			if need > wait {
+				wait = need
+			}
+		} else if b.tokens < 1 {
+			l.rejectLocked(keys)
+			l.mu.Unlock()
+			return &ErrLimited{Key: key, Reason: "rate is zero"}
+		}
+	}
+
+	if wait > maxWait {
+		l.rejectLocked(keys)
+		l.mu.Unlock()
+		return &ErrLimited{Key: keys[0], Reason: "queue wait too long"}
+	}
+
+	// Reserve now (tokens may go negative) so concurrent callers queue behind us
+	for _, key := range keys {
+		b := l.buckets[key]
+		b.tokens--
+		b.used++
+	}
+	l.mu.Unlock()
+
+	if wait > 0 {
+		time.Sleep(wait)
+	}
+	return nil
+}
+
+func (l *Limiter) rejectLocked(keys []string) {
+	for _, key := range keys {
+		l.buckets[key].rejected++
+	}
+}
+
+// Usage returns a snapshot of every bucket, sorted by key
+func (l *Limiter) Usage() []Usage {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	list := make([]Usage, 0, len(l.buckets))
+	for key, b := range l.buckets {
+		b.refill(now)
+		list = append(list, Usage{
+			Key:        key,
+			Rate:       b.rule.Rate,
+			Burst:      b.rule.Burst,
+			Tokens:     b.tokens,
+			DailyQuota: b.rule.DailyQuota,
+			UsedToday:  b.used,
+			Rejected:   b.rejected,
+			Day:        b.day,
+		})
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Key < list[j].Key })
+	return list
+}
@@ -0,0 +1,286 @@
+// Package sitemap writes gzipped XML sitemaps of the local catalog to disk.
+// Up to MaxURLs it is a single urlset; past that, sitemap.xml becomes an index
+// of numbered child sitemaps.
+package sitemap
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"dramabang/models"
+	"encoding/xml"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// MaxURLs is the sitemap protocol limit per file
+const MaxURLs = 50000
+
+// Dir is where generated files are kept and served from
+const Dir = "public/sitemaps"
+
+// IndexFile is the entry point served at /sitemap.xml
+const IndexFile = "sitemap.xml.gz"
+
+// batchSize is how many dramas are read per query
+const batchSize = 1000
+
+// staticPages are listed first with no lastmod
+var staticPages = []string{"/", "/search"}
+
+// Result summarizes one generation
+type Result struct {
+	URLs     int `json:"urls"`
+	Dramas   int `json:"dramas"`
+	Episodes int `json:"episodes"`
+	Genres   int `json:"genres"`
+	Files    int `json:"files"` // Child sitemaps; 0 when sitemap.xml is a single urlset
+}
+
+// SiteURL is the public origin used in <loc>, from SITE_URL
+func SiteURL() string {
+	if u := strings.TrimRight(os.Getenv("SITE_URL"), "/"); u != "" {
+		return u
+	}
+	return "https://dramaplay.online"
+}
+
+// ChildName is the file name of the n-th (1-based) child sitemap
+func ChildName(n int) string {
+	return fmt.Sprintf("sitemap-%d.xml.gz", n)
+}
+
+type entry struct {
+	loc     string
+	lastmod *time.Time
+}
+
+// writer splits entries into files of at most MaxURLs
+type writer struct {
+	dir   string
+	files []string // Finished child files, in order
+	buf   bytes.Buffer
+	count int
+}
+
+func (w *writer) add(e entry) error {
+	if w.count == MaxURLs {
+		if err := w.flush(); err != nil {
+			return err
+		}
+	}
+	if w.count == 0 {
+		w.buf.WriteString(xml.Header + `<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">` + "\n")
+	}
+	w.buf.WriteString("<url><loc>")
+	xml.EscapeText(&w.buf, []byte(e.loc))
+	w.buf.WriteString("</loc>")
+	if e.lastmod != nil && !e.lastmod.IsZero() {
+		w.buf.WriteString("<lastmod>" + e.lastmod.UTC().Format(time.RFC3339) + "</lastmod>")
+	}
+	w.buf.WriteString("</url>\n")
+	w.count++
+	return nil
+}
+
+func (w *writer) flush() error {
+	if w.count == 0 {
+		return nil
+	}
+	w.buf.WriteString("</urlset>\n")
+	name := ChildName(len(w.files) + 1)
+	if err := writeGzip(filepath.Join(w.dir, name), w.buf.Bytes()); err != nil {
+		return err
+	}
+	w.files = append(w.files, name)
+	w.buf.Reset()
+	w.count = 0
+	return nil
+}
+
+// Generate writes sitemaps for static pages, available dramas, their episodes
+// and visible genres. lastmod comes from ingest timestamps.
+func Generate(ctx context.Context, db *gorm.DB, base string) (*Result, error) {
+	if err := os.MkdirAll(Dir, 0755); err != nil {
+		return nil, err
+	}
+	w := &writer{dir: Dir}
+	res := &Result{}
+
+	for _, p := range staticPages {
+		if err := w.add(entry{loc: base + p}); err != nil {
+			return nil, err
+		}
+	}
+
+	// Latest metadata change per drama (title, cover or episode count)
+	type change struct {
+		BookID string
+		At     string // max() loses the column type on sqlite, so parse it ourselves
+	}
+	var changes []change
+	db.Model(&models.DramaSnapshot{}).Select("book_id, max(created_at) as at").Group("book_id").Scan(&changes)
+	changed := make(map[string]time.Time, len(changes))
+	for _, c := range changes {
+		if t, ok := parseTime(c.At); ok {
+			changed[c.BookID] = t
+		}
+	}
+
+	type epCount struct {
+		BookID string
+		Count  int
+	}
+	var eps []epCount
+	db.Model(&models.Episode{}).Select("book_id, count(*) as count").Group("book_id").Scan(&eps)
+	episodes := make(map[string]int, len(eps))
+	for _, e := range eps {
+		episodes[e.BookID] = e.Count
+	}
+
+	var batch []models.Drama
+	err := db.Model(&models.Drama{}).
+		Select("book_id, created_at, episodes_updated_at").
+		Where("availability IS NULL OR availability <> ?", models.AvailabilityUnavailable).
+		Order("book_id").
+		FindInBatches(&batch, batchSize, func(tx *gorm.DB, _ int) error {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			for _, d := range batch {
+				lastmod := lastModified(d, changed[d.BookID])
+				id := escapeID(d.BookID)
+				if err := w.add(entry{loc: base + "/detail/" + id, lastmod: lastmod}); err != nil {
+					return err
+				}
+				res.Dramas++
+				for n := 1; n <= episodes[d.BookID]; n++ {
+					if err := w.add(entry{loc: fmt.Sprintf("%s/watch/%s/%d", base, id, n), lastmod: lastmod}); err != nil {
+						return err
+					}
+					res.Episodes++
+				}
+			}
+			return nil
+		}).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var genres []models.Genre
+	db.Where("hidden = ? AND id IN (?)", false, db.Model(&models.DramaGenre{}).Select("genre_id")).Order("slug").Find(&genres)
+	for _, g := range genres {
+		if err := w.add(entry{loc: base + "/search?category=" + url.QueryEscape(g.Slug)}); err != nil {
+			return nil, err
+		}
+		res.Genres++
+	}
+
+	res.URLs = len(staticPages) + res.Dramas + res.Episodes + res.Genres
+	if err := w.flush(); err != nil {
+		return nil, err
+	}
+
+	if len(w.files) == 1 {
+		// Everything fits in one file: serve it directly as sitemap.xml
+		if err := os.Rename(filepath.Join(Dir, w.files[0]), filepath.Join(Dir, IndexFile)); err != nil {
+			return nil, err
+		}
+		w.files = nil
+	} else if err := writeIndex(base, w.files); err != nil {
+		return nil, err
+	}
+	res.Files = len(w.files)
+
+	removeStale(len(w.files))
+	return res, nil
+}
+
+// lastModified is the newest of creation, episode growth and metadata change
+func lastModified(d models.Drama, changed time.Time) *time.Time {
+	var latest time.Time
+	for _, t := range []*time.Time{d.CreatedAt, d.EpisodesUpdatedAt, &changed} {
+		if t != nil && t.After(latest) {
+			latest = *t
+		}
+	}
+	if latest.IsZero() {
+		return nil
+	}
+	return &latest
+}
+
+// timeLayouts are the text forms of a timestamp aggregate: sqlite's stored
+// string and the RFC 3339 form other drivers convert to
+var timeLayouts = []string{
+	"2006-01-02 15:04:05.999999999-07:00",
+	"2006-01-02 15:04:05.999999999",
+	time.RFC3339Nano,
+}
+
+func parseTime(s string) (time.Time, bool) {
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// escapeID keeps the ":" of provider IDs readable while escaping the rest
+func escapeID(id string) string {
+	return strings.ReplaceAll(url.PathEscape(id), "%3A", ":")
+}
+
+func writeIndex(base string, files []string) error {
+	var buf bytes.Buffer
+	now := time.Now().UTC().Format(time.RFC3339)
+	buf.WriteString(xml.Header + `<sitemapindex xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">` + "\n")
+	for _, f := range files {
+		loc := base + "/sitemaps/" + strings.TrimSuffix(f, ".gz")
+		buf.WriteString("<sitemap><loc>")
+		xml.EscapeText(&buf, []byte(loc))
+		buf.WriteString("</loc><lastmod>" + now + "</lastmod></sitemap>\n")
+	}
+	buf.WriteString("</sitemapindex>\n")
+	return writeGzip(filepath.Join(Dir, IndexFile), buf.Bytes())
+}
+
+// writeGzip replaces path atomically so readers never see a partial file
+func writeGzip(path string, data []byte) error {
+	tmp := path + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	gz := gzip.NewWriter(f)
+	if _, err := gz.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// removeStale deletes child files beyond the current count
+func removeStale(keep int) {
+	matches, _ := filepath.Glob(filepath.Join(Dir, "sitemap-*.xml.gz"))
+	for _, m := range matches {
+		var n int
+		if _, err := fmt.Sscanf(filepath.Base(m), "sitemap-%d.xml.gz", &n); err == nil && n > keep {
+			os.Remove(m)
+		}
+	}
+}
@@ -0,0 +1,444 @@
+// Package suggest keeps an in-memory index of catalog titles for autocomplete
+// and typo-tolerant lookups. Titles are split into words; a trigram index over
+// the vocabulary finds candidate corrections, which are confirmed by edit distance.
+package suggest
+
+import (
+	"dramabang/models"
+	"dramabang/services/titles"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// RefreshInterval is how often the index is rebuilt from the dramas table
+	RefreshInterval = 10 * time.Minute
+	// maxQueries bounds the popular query counter
+	maxQueries = 5000
+	// prefixScore is what a word completion counts for against an exact word (1)
+	prefixScore = 0.9
+)
+
+// Title is one autocomplete completion
+type Title struct {
+	BookID string `json:"bookId"`
+	Title  string `json:"title"`
+	Cover  string `json:"cover,omitempty"`
+}
+
+// Query is a popular search with how often it was made
+type Query struct {
+	Query string `json:"query"`
+	Count int    `json:"count"`
+}
+
+// Match is a catalog drama found by fuzzy lookup
+type Match struct {
+	Title
+	Score float64 // Summed per-word scores
+}
+
+type entry struct {
+	Title
+	key        string // titles.Key of the title
+	popularity int64
+}
+
+// Index is safe for concurrent use; Build swaps contents atomically
+type Index struct {
+	mu       sync.RWMutex
+	entries  []entry
+	byKey    []int            // Entry indexes sorted by key, for title prefixes
+	words    []string         // Vocabulary, sorted
+	postings map[string][]int // Word -> entry indexes
+	trigrams map[string][]int // Trigram -> vocabulary indexes
+
+	qmu     sync.Mutex
+	queries map[string]int
+}
+
+// Default is the index used by the API
+var Default = &Index{queries: map[string]int{}}
+
+// StartRefresh builds the index now and then every interval in the background
+func (ix *Index) StartRefresh(db *gorm.DB, interval time.Duration) {
+	go func() {
+		for {
+			if err := ix.Build(db); err != nil {
+				fmt.Println("Suggest index build failed:", err)
+			}
+			time.Sleep(interval)
+		}
+	}()
+}
+
+// Build loads every available drama's title
+func (ix *Index) Build(db *gorm.DB) error {
+	var rows []models.Drama
+	err := db.Model(&models.Drama{}).
+		Select("book_id, judul, canonical_title, cover, likes_count, views_count").
+		Where("availability IS NULL OR availability <> ?", models.AvailabilityUnavailable).
+		Find(&rows).Error
+	if err != nil {
+		return err
+	}
+
+	entries := make([]entry, 0, len(rows))
+	postings := make(map[string][]int)
+	for _, d := range rows {
+		title := d.CanonicalTitle
+		if title == "" {
+			title = d.Judul
+		}
+		key := titles.Key(title)
+		if key == "" {
+			continue
+		}
+		i := len(entries)
+		entries = append(entries, entry{
+			Title:      Title{BookID: d.BookID, Title: title, Cover: d.Cover},
+			key:        key,
+			popularity: d.LikesCount + d.ViewsCount,
+		})
+		seen := make(map[string]bool)
+		for _, w := range strings.Fields(key) {
+			if !seen[w] {
+				seen[w] = true
+				postings[w] = append(postings[w], i)
+			}
+		}
+	}
+
+	byKey := make([]int, len(entries))
+	for i := range byKey {
+		byKey[i] = i
+	}
+	sort.Slice(byKey, func(a, b int) bool { return entries[byKey[a]].key < entries[byKey[b]].key })
+
+	words := make([]string, 0, len(postings))
+	for w := range postings {
+		words = append(words, w)
+	}
+	sort.Strings(words)
+	trigrams := make(map[string][]int)
+	for i, w := range words {
+		for _, g := range trigramsOf(w) {
+			trigrams[g] = append(trigrams[g], i)
+		}
+	}
+
+	ix.mu.Lock()
+	ix.entries, ix.byKey, ix.words, ix.postings, ix.trigrams = entries, byKey, words, postings, trigrams
+	ix.mu.Unlock()
+	return nil
+}
+
+// Size is the number of indexed titles
+func (ix *Index) Size() int {
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+	return len(ix.entries)
+}
+
+// Complete returns titles starting with the typed text, then titles whose words
+// cover it (the last word as a prefix), most popular first
+func (ix *Index) Complete(text string, limit int) []Title {
+	key := titles.Key(text)
+	if key == "" {
+		return []Title{}
+	}
+
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+
+	out := make([]Title, 0, limit)
+	picked := make(map[int]bool)
+
+	// Whole-title prefix matches
+	start := sort.Search(len(ix.byKey), func(i int) bool { return ix.entries[ix.byKey[i]].key >= key })
+	var prefixed []int
+	for i := start; i < len(ix.byKey) && strings.HasPrefix(ix.entries[ix.byKey[i]].key, key); i++ {
+		prefixed = append(prefixed, ix.byKey[i])
+	}
+	ix.byPopularity(prefixed)
+	for _, e := range prefixed {
+		if len(out) == limit {
+			return out
+		}
+		picked[e] = true
+		out = append(out, ix.entries[e].Title)
+	}
+
+	// Word matches anywhere in the title
+	var rest []int
+	for e := range ix.matchWords(strings.Fields(key)) {
+		if !picked[e] {
+			rest = append(rest, e)
+		}
+	}
+	ix.byPopularity(rest)
+	for _, e := range rest {
+		if len(out) == limit {
+			break
+		}
+		out = append(out, ix.entries[e].Title)
+	}
+	return out
+}
+
+// Fuzzy finds titles whose words match the query's words, tolerating typos.
+// At least half of the query words must match. corrected rewrites the query with
+// the vocabulary words that matched, for a "did you mean" hint.
+func (ix *Index) Fuzzy(text string, limit int) (matches []Match, corrected string) {
+	terms := strings.Fields(titles.Key(text))
+	if len(terms) == 0 {
+		return nil, ""
+	}
+
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+
+	scores := make(map[int]float64)
+	hits := make(map[int]int)
+	fixed := make([]string, len(terms))
+	for i, t := range terms {
+		fixed[i] = t
+		best := 0.0
+		termBest := make(map[int]float64) // Best word per entry, so a term counts once
+		for w, s := range ix.candidates(t, i == len(terms)-1) {
+			if s > best || (s == best && w < fixed[i]) {
+				best, fixed[i] = s, w
+			}
+			for _, e := range ix.postings[w] {
+				termBest[e] = max(termBest[e], s)
+			}
+		}
+		for e, s := range termBest {
+			scores[e] += s
+			hits[e]++
+		}
+	}
+
+	need := (len(terms) + 1) / 2
+	var found []int
+	for e := range scores {
+		if hits[e] >= need {
+			found = append(found, e)
+		}
+	}
+	ix.byPopularity(found)
+	sort.SliceStable(found, func(a, b int) bool { return scores[found[a]] > scores[found[b]] })
+	if len(found) > limit {
+		found = found[:limit]
+	}
+	for _, e := range found {
+		matches = append(matches, Match{Title: ix.entries[e].Title, Score: scores[e]})
+	}
+	return matches, strings.Join(fixed, " ")
+}
+
+// candidates maps vocabulary words close to term to a score in (0, 1]
+func (ix *Index) candidates(term string, prefix bool) map[string]float64 {
+	out := make(map[string]float64)
+	if _, ok := ix.postings[term]; ok {
+		out[term] = 1
+		return out
+	}
+	if prefix && len(term) >= 3 {
+		start := sort.SearchStrings(ix.words, term)
+		for i := start; i < len(ix.words) && strings.HasPrefix(ix.words[i], term); i++ {
+			out[ix.words[i]] = prefixScore
+		}
+	}
+
+	maxDist := maxEdits(term)
+	if maxDist == 0 {
+		return out
+	}
+	shared := make(map[int]int)
+	for _, g := range trigramsOf(term) {
+		for _, w := range ix.trigrams[g] {
+			shared[w]++
+		}
+	}
+	for w, n := range shared {
+		word := ix.words[w]
+		if n < 2 || abs(len(word)-len(term)) > maxDist {
+			continue
+		}
+		if d := editDistance(term, word, maxDist); d <= maxDist {
+			s := 1 - float64(d)/float64(len(term)+1)
+			if s > out[word] {
+				out[word] = s
+			}
+		}
+	}
+	return out
+}
+
+// matchWords returns entries containing every term, the last one as a prefix
+func (ix *Index) matchWords(terms []string) map[int]bool {
+	var result map[int]bool
+	for i, t := range terms {
+		found := make(map[int]bool)
+		if i == len(terms)-1 {
+			start := sort.SearchStrings(ix.words, t)
+			for j := start; j < len(ix.words) && strings.HasPrefix(ix.words[j], t); j++ {
+				for _, e := range ix.postings[ix.words[j]] {
+					found[e] = true
+				}
+			}
+		} else {
+			for _, e := range ix.postings[t] {
+				found[e] = true
+			}
+		}
+		if result == nil {
+			result = found
+			continue
+		}
+		for e := range result {
+			if !found[e] {
+				delete(result, e)
+			}
+		}
+	}
+	return result
+}
+
+func (ix *Index) byPopularity(list []int) {
+	sort.SliceStable(list, func(a, b int) bool {
+		ea, eb := ix.entries[list[a]], ix.entries[list[b]]
+		if ea.popularity != eb.popularity {
+			return ea.popularity > eb.popularity
+		}
+		return ea.key < eb.key
+	})
+}
+
+// RecordQuery counts a search that returned results
+func (ix *Index) RecordQuery(text string) {
+	key := titles.Key(text)
+	if key == "" {
+		return
+	}
+	ix.qmu.Lock()
+	defer ix.qmu.Unlock()
+	ix.queries[key]++
+	ix.trimQueriesLocked()
+}
+
+// trimQueriesLocked drops the least made queries once the counter is over
+// maxQueries, keeping 90% so trimming is not repeated on every new query;
+// caller must hold ix.qmu
+func (ix *Index) trimQueriesLocked() {
+	if len(ix.queries) <= maxQueries {
+		return
+	}
+	list := make([]Query, 0, len(ix.queries))
+	for q, n := range ix.queries {
+		list = append(list, Query{Query: q, Count: n})
+	}
+	sort.Slice(list, func(a, b int) bool { return list[a].Count < list[b].Count })
+	for _, q := range list[:len(list)-maxQueries*9/10] {
+		delete(ix.queries, q.Query)
+	}
+}
+
+// PopularQueries returns recorded queries starting with text ("" for all), most made first
+func (ix *Index) PopularQueries(text string, limit int) []Query {
+	key := titles.Key(text)
+	ix.qmu.Lock()
+	out := make([]Query, 0)
+	for q, n := range ix.queries {
+		if strings.HasPrefix(q, key) && q != key {
+			out = append(out, Query{Query: q, Count: n})
+		}
+	}
+	ix.qmu.Unlock()
+
+	sort.Slice(out, func(a, b int) bool {
+		if out[a].Count != out[b].Count {
+			return out[a].Count > out[b].Count
+		}
+		return out[a].Query < out[b].Query
+	})
+	if len(out) > limit {
+		out = out[:limit]
+	}
+	return out
+}
+
+// maxEdits allows one typo in short words and two in longer ones
+func maxEdits(term string) int {
+	switch n := len([]rune(term)); {
+	case n < 4:
+		return 0
+	case n < 8:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// trigramsOf pads the word so short words and word edges still produce trigrams
+func trigramsOf(word string) []string {
+	r := []rune("  " + word + " ")
+	out := make([]string, 0, len(r)-2)
+	for i := 0; i+3 <= len(r); i++ {
+		out = append(out, string(r[i:i+3]))
+	}
+	return out
+}
+
+// editDistance is the Levenshtein distance, giving up once it exceeds max
+func editDistance(a, b string, max int) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		rowMin := cur[0]
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+			rowMin = min(rowMin, cur[j])
+		}
+		if rowMin > max {
+			return max + 1
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(rb)]
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// SeedQuery sets a query's count when it is higher than the recorded one
+func (ix *Index) SeedQuery(key string, count int) {
+	if key == "" {
+		return
+	}
+	ix.qmu.Lock()
+	defer ix.qmu.Unlock()
+	if count > ix.queries[key] {
+		ix.queries[key] = count
+		ix.trimQueriesLocked()
+	}
+}
@@ -0,0 +1,198 @@
+// Package titles turns upstream drama titles into a canonical form and pulls
+// out the release attributes (dub, subtitles, audio language) they carry.
+package titles
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Info is the parsed form of a raw title
+type Info struct {
+	Canonical     string // Display title without variant markers, e.g. "Awas! Sang Naga Kembali"
+	Key           string // Lowercase letters and digits only, used for matching
+	Dubbed        bool
+	Subtitled     bool
+	AudioLanguage string // Locale code of the dub when the marker names one
+}
+
+// widthReplacer maps CJK punctuation and typographic quotes to ASCII
+var widthReplacer = strings.NewReplacer(
+	"【", "[", "】", "]", "「", "\"", "」", "\"", "『", "\"", "』", "\"",
+	"“", "\"", "”", "\"", "‘", "'", "’", "'", "…", "...", "、", ",", "。", ".",
+)
+
+// bracketRe matches a (...) or [...] group; a group left open at the end of the title also counts
+var bracketRe = regexp.MustCompile(`\s*[(\[]([^()\[\]]*)(?:[)\]]|$)`)
+
+// separatorRe matches a trailing " - Marker" or " | Marker" segment
+var separatorRe = regexp.MustCompile(`\s+[-|–]\s+([^-|–]+)$`)
+
+// gapRe finds punctuation glued to the next word, as in "Awas!Sang"
+var gapRe = regexp.MustCompile(`([!?:;,])(\p{L})`)
+
+var dubWords = []string{"sulih suara", "dubbing", "dubbed", "dub", "dublado", "doblado", "พากย์", "配音"}
+var subWords = []string{"subtitle", "subtitled", "sub indo", "sub", "teks", "字幕", "ซับ"}
+
+// audioWords names the dub language in a marker, e.g. "English Dub". It is a
+// slice so a marker naming two languages always resolves to the same one
+// (the earlier entry), keeping dedup keys stable between runs.
+var audioWords = []struct{ word, code string }{
+	{"indonesia", "id"}, {"indo", "id"}, {"sulih suara", "id"},
+	{"english", "en"}, {"inggris", "en"},
+	{"thai", "th"}, {"ไทย", "th"}, {"พากย์ไทย", "th"},
+	{"español", "es"}, {"spanish", "es"}, {"doblado", "es"},
+	{"português", "pt"}, {"portuguese", "pt"}, {"dublado", "pt"},
+}
+
+// Parse normalizes a raw upstream title
+func Parse(raw string) Info {
+	title := Fold(raw)
+
+	var info Info
+	strip := func(marker string) bool {
+		dub, sub, lang := classify(marker)
+		if !dub && !sub {
+			return false
+		}
+		info.Dubbed = info.Dubbed || dub
+		info.Subtitled = info.Subtitled || sub
+		if info.AudioLanguage == "" && dub {
+			info.AudioLanguage = lang
+		}
+		return true
+	}
+
+	title = bracketRe.ReplaceAllStringFunc(title, func(group string) string {
+		inner := bracketRe.FindStringSubmatch(group)[1]
+		if strip(inner) {
+			return ""
+		}
+		return group
+	})
+	if m := separatorRe.FindStringSubmatch(title); m != nil && strip(m[1]) {
+		title = title[:len(title)-len(m[0])]
+	}
+
+	title = gapRe.ReplaceAllString(title, "$1 $2")
+	info.Canonical = strings.Join(strings.Fields(title), " ")
+	info.Key = Key(info.Canonical)
+	return info
+}
+
+// Fold converts fullwidth forms to ASCII and collapses whitespace
+func Fold(s string) string {
+	s = widthReplacer.Replace(s)
+	s = strings.Map(func(r rune) rune {
+		switch {
+		case r >= 0xFF01 && r <= 0xFF5E: // Fullwidth ASCII block
+			return r - 0xFEE0
+		case r == 0x3000 || unicode.IsSpace(r):
+			return ' '
+		case unicode.Is(unicode.Cf, r): // Zero-width and direction marks
+			return -1
+		}
+		return r
+	}, s)
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// Key reduces text to lowercase words of letters and digits; search queries
+// and titles compare equal when their keys do
+func Key(s string) string {
+	s = strings.ToLower(Fold(s))
+	s = strings.Map(func(r rune) rune {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsNumber(r) || unicode.Is(unicode.Mn, r):
+			return r
+		case r == '\'': // "CEO's" -> "ceos"
+			return -1
+		}
+		return ' '
+	}, s)
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// classify reports whether a marker names a dubbed or subtitled release
+func classify(marker string) (dub, sub bool, lang string) {
+	m := strings.ToLower(strings.TrimSpace(marker))
+	if m == "" {
+		return false, false, ""
+	}
+	words := " " + Key(m) + " "
+	has := func(w string) bool {
+		// Latin markers must match whole words so "Dubai" isn't a dub
+		if isLatin(w) {
+			return strings.Contains(words, " "+w+" ")
+		}
+		return strings.Contains(m, w)
+	}
+
+	for _, w := range dubWords {
+		if has(w) {
+			dub = true
+			break
+		}
+	}
+	if !dub {
+		for _, w := range subWords {
+			if has(w) {
+				sub = true
+				break
+			}
+		}
+	}
+	if !dub && !sub {
+		return false, false, ""
+	}
+
+	// The marker must be only attribute words, otherwise it's part of the title
+	for _, w := range strings.Fields(Key(m)) {
+		if !isAttributeWord(w) {
+			return false, false, ""
+		}
+	}
+
+	for _, a := range audioWords {
+		if has(a.word) {
+			lang = a.code
+			break
+		}
+	}
+	return dub, sub, lang
+}
+
+// attributeWords may appear in a variant marker alongside the dub/sub word
+var attributeWords = map[string]bool{
+	"versi": true, "version": true, "ver": true, "bahasa": true, "audio": true, "full": true, "hd": true, "cc": true,
+}
+
+func init() {
+	// Every word of the dub, sub and language markers is an attribute word too
+	for _, list := range [][]string{dubWords, subWords} {
+		for _, phrase := range list {
+			for _, w := range strings.Fields(Key(phrase)) {
+				attributeWords[w] = true
+			}
+		}
+	}
+	for _, a := range audioWords {
+		for _, w := range strings.Fields(Key(a.word)) {
+			attributeWords[w] = true
+		}
+	}
+}
+
+func isAttributeWord(w string) bool {
+	return attributeWords[w]
+}
+
+func isLatin(s string) bool {
+	for _, r := range s {
+		if r > unicode.MaxLatin1 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,124 @@
+package utils
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// ErrNoEncryptionKey is returned when neither CREDENTIAL_KEY nor ADMIN_SECRET is set
+var ErrNoEncryptionKey = errors.New("CREDENTIAL_KEY or ADMIN_SECRET must be set to encrypt secrets at rest")
+
+// encryptionKey derives the AES-256 key used for secrets at rest.
+// CREDENTIAL_KEY is preferred; ADMIN_SECRET is used as a fallback. There is
+// deliberately no built-in key: anyone with the source could decrypt with it.
+func encryptionKey() ([]byte, error) {
+	secret := os.Getenv("CREDENTIAL_KEY")
+	if secret == "" {
+		secret = os.Getenv("ADMIN_SECRET")
+	}
+	if secret == "" {
+		return nil, ErrNoEncryptionKey
+	}
+	sum := sha256.Sum256([]byte(secret))
+	return sum[:], nil
+}
+
+// CheckEncryptionKey reports whether a secret for EncryptString is configured
+func CheckEncryptionKey() error {
+	_, err := encryptionKey()
+	return err
+}
+
+// EncryptString seals plaintext with AES-GCM and returns base64(nonce|ciphertext)
+func EncryptString(plaintext string) (string, error) {
+	key, err := encryptionKey()
+	if err != nil {
+		return "", err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return "", err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+
+	sealed := gcm.Seal(nonce, nonce, []byte(plaintext), nil)
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// DecryptString reverses EncryptString
+func DecryptString(encoded string) (string, error) {
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", err
+	}
+
+	key, err := encryptionKey()
+	if err != nil {
+		return "", err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return "", err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return "", err
+	}
+
+	if len(data) < gcm.NonceSize() {
+		return "", errors.New("ciphertext too short")
+	}
+
+	nonce, ciphertext := data[:gcm.NonceSize()], data[gcm.NonceSize():]
+	plain, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", err
+	}
+	return string(plain), nil
+}
+
+// anonSalt keys AnonymousID. It is random, never stored and replaced each UTC
+// day, so once a day is over nobody (not even us) can recompute its hashes.
+var anonSalt struct {
+	sync.Mutex
+	day string
+	key []byte
+}
+
+// AnonymousID hashes an identifier (user ID, IP) into a short token that is
+// stable for one UTC day (or until restart) and can't be linked across days or reversed
+func AnonymousID(id string) string {
+	day := time.Now().UTC().Format("2006-01-02")
+	anonSalt.Lock()
+	if anonSalt.day != day {
+		key := make([]byte, 32)
+		if _, err := rand.Read(key); err != nil {
+			panic(err) // crypto/rand does not fail on supported platforms
+		}
+		anonSalt.day, anonSalt.key = day, key
+	}
+	key := anonSalt.key
+	anonSalt.Unlock()
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(id))
+	return hex.EncodeToString(mac.Sum(nil))[:16]
+}